@@ -0,0 +1,486 @@
+// Package abi implements the Solidity contract ABI used by TRON smart
+// contracts: parsing of ABI JSON (standard or as returned by the node),
+// encoding of calls, decoding of outputs, events and revert reasons.
+// Addresses are TRON addresses, encoded as their last 20 bytes.
+package abi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+var (
+	// ErrNotFound is returned when an ABI has no method or event with the
+	// requested name, signature or id.
+	ErrNotFound = errors.New("abi: method or event not found")
+	// ErrAmbiguous is returned when a name matches several overloaded
+	// methods or events; use the signature instead.
+	ErrAmbiguous = errors.New("abi: ambiguous overloaded name, use the signature")
+	// ErrNotRevert is returned by DecodeRevert when the data is neither an
+	// Error(string) nor a Panic(uint256).
+	ErrNotRevert = errors.New("abi: not a revert reason")
+)
+
+// Selector returns the 4 bytes selector of the function signature sig, e.g.
+// "transfer(address,uint256)".
+func Selector(sig string) []byte {
+	return address.Keccak256([]byte(sig))[:4]
+}
+
+// EventTopic returns the topic of the event signature sig, e.g.
+// "Transfer(address,address,uint256)".
+func EventTopic(sig string) []byte {
+	return address.Keccak256([]byte(sig))
+}
+
+// Argument is an input or output of a method or event.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+// Arguments are the inputs or outputs of a method or event.
+type Arguments []Argument
+
+// Types returns the types of a.
+func (a Arguments) Types() []Type {
+	types := make([]Type, len(a))
+	for i, arg := range a {
+		types[i] = arg.Type
+	}
+	return types
+}
+
+// Pack encodes values as a, see Encode.
+func (a Arguments) Pack(values ...interface{}) ([]byte, error) {
+	return Encode(a.Types(), values)
+}
+
+// Unpack decodes data encoded as a, see Decode.
+func (a Arguments) Unpack(data []byte) ([]interface{}, error) {
+	return Decode(a.Types(), data)
+}
+
+// UnpackMap decodes data encoded as a into a map keyed by argument name.
+func (a Arguments) UnpackMap(data []byte) (map[string]interface{}, error) {
+	values, err := a.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{}, len(values))
+	for i, v := range values {
+		m[a[i].Name] = v
+	}
+	return m, nil
+}
+
+func (a Arguments) signature() string {
+	parts := make([]string, len(a))
+	for i, arg := range a {
+		parts[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(parts, ",") + ")"
+}
+
+// Method is a contract function, or the constructor when Name is empty.
+type Method struct {
+	Name    string
+	Inputs  Arguments
+	Outputs Arguments
+	// StateMutability is pure, view, nonpayable or payable.
+	StateMutability string
+	// Sig is the canonical signature, e.g. "transfer(address,uint256)".
+	Sig string
+	// ID is the selector, empty for the constructor.
+	ID []byte
+}
+
+func newMethod(name string, inputs, outputs Arguments, mutability string) Method {
+	m := Method{Name: name, Inputs: inputs, Outputs: outputs, StateMutability: mutability}
+	m.Sig = name + inputs.signature()
+	if name != "" {
+		m.ID = Selector(m.Sig)
+	}
+	return m
+}
+
+// ParseMethod parses a function signature such as
+// "transfer(address,uint256)", optionally followed by its outputs, e.g.
+// "balanceOf(address) returns (uint256)". Arguments are unnamed.
+func ParseMethod(sig string) (*Method, error) {
+	sig = strings.TrimSpace(sig)
+	open := strings.Index(sig, "(")
+	if open <= 0 {
+		return nil, fmt.Errorf("abi: invalid signature %q", sig)
+	}
+	name := sig[:open]
+
+	inputs, rest, err := parseTypeList(sig[open+1:])
+	if err != nil {
+		return nil, err
+	}
+	var outputs []Type
+	if rest = strings.TrimSpace(rest); rest != "" {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "returns"))
+		if !strings.HasPrefix(rest, "(") {
+			return nil, fmt.Errorf("abi: invalid signature %q", sig)
+		}
+		if outputs, rest, err = parseTypeList(rest[1:]); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("abi: invalid signature %q", sig)
+		}
+	}
+
+	m := newMethod(name, typesArguments(inputs), typesArguments(outputs), "")
+	return &m, nil
+}
+
+func typesArguments(types []Type) Arguments {
+	args := make(Arguments, len(types))
+	for i, t := range types {
+		args[i] = Argument{Type: t}
+	}
+	return args
+}
+
+// IsConstant reports whether m does not modify the state and can be called
+// with a constant call.
+func (m *Method) IsConstant() bool {
+	return m.StateMutability == "view" || m.StateMutability == "pure"
+}
+
+// Pack encodes a call of m with args: the selector followed by the encoded
+// arguments. For the constructor only the arguments are encoded.
+func (m *Method) Pack(args ...interface{}) ([]byte, error) {
+	enc, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(nil), m.ID...), enc...), nil
+}
+
+// Unpack decodes the output of a call of m.
+func (m *Method) Unpack(output []byte) ([]interface{}, error) {
+	return m.Outputs.Unpack(output)
+}
+
+// Event is a contract event.
+type Event struct {
+	Name      string
+	Inputs    Arguments
+	Anonymous bool
+	// Sig is the canonical signature, e.g.
+	// "Transfer(address,address,uint256)".
+	Sig string
+	// ID is the topic of the event, the first topic of its logs unless it is
+	// anonymous.
+	ID []byte
+}
+
+func newEvent(name string, inputs Arguments, anonymous bool) Event {
+	e := Event{Name: name, Inputs: inputs, Anonymous: anonymous}
+	e.Sig = name + inputs.signature()
+	e.ID = EventTopic(e.Sig)
+	return e
+}
+
+// DecodeLog decodes the topics and data of a log of e into a map keyed by
+// argument name. Indexed arguments of dynamic types are only known by their
+// hash, returned as a 32 bytes []byte.
+func (e *Event) DecodeLog(topics [][]byte, data []byte) (map[string]interface{}, error) {
+	if !e.Anonymous {
+		if len(topics) == 0 || string(topics[0]) != string(e.ID) {
+			return nil, fmt.Errorf("abi: log is not a %s event", e.Name)
+		}
+		topics = topics[1:]
+	}
+
+	var indexed, plain Arguments
+	for _, arg := range e.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		} else {
+			plain = append(plain, arg)
+		}
+	}
+	if len(topics) != len(indexed) {
+		return nil, fmt.Errorf("abi: %d topics for %d indexed arguments", len(topics), len(indexed))
+	}
+
+	values, err := plain.UnpackMap(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, arg := range indexed {
+		if len(topics[i]) != 32 {
+			return nil, ErrInvalidData
+		}
+		if arg.Type.IsDynamic() || arg.Type.Kind == ArrayKind || arg.Type.Kind == TupleKind {
+			values[arg.Name] = topics[i]
+			continue
+		}
+		v, err := decode(arg.Type, topics[i])
+		if err != nil {
+			return nil, err
+		}
+		values[arg.Name] = v
+	}
+	return values, nil
+}
+
+// ABI is the interface of a contract.
+type ABI struct {
+	Constructor *Method
+	Methods     []Method
+	Events      []Event
+}
+
+type jsonArgument struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Indexed    bool           `json:"indexed"`
+	Components []jsonArgument `json:"components"`
+}
+
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Constant        bool           `json:"constant"`
+	Payable         bool           `json:"payable"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// Parse parses an ABI JSON: either the standard array of entries, or the
+// {"entrys": [...]} object returned by the node, whose types and state
+// mutabilities are capitalized.
+func Parse(data []byte) (*ABI, error) {
+	var entries []jsonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var tron struct {
+			Entrys []jsonEntry `json:"entrys"`
+		}
+		if err2 := json.Unmarshal(data, &tron); err2 != nil {
+			return nil, fmt.Errorf("abi: %w", err)
+		}
+		entries = tron.Entrys
+	}
+
+	var abi ABI
+	for _, e := range entries {
+		inputs, err := newArguments(e.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := newArguments(e.Outputs)
+		if err != nil {
+			return nil, err
+		}
+
+		mutability := strings.ToLower(e.StateMutability)
+		if mutability == "" {
+			switch {
+			case e.Constant:
+				mutability = "view"
+			case e.Payable:
+				mutability = "payable"
+			default:
+				mutability = "nonpayable"
+			}
+		}
+
+		switch strings.ToLower(e.Type) {
+		case "function", "":
+			abi.Methods = append(abi.Methods, newMethod(e.Name, inputs, outputs, mutability))
+		case "constructor":
+			m := newMethod("", inputs, nil, mutability)
+			abi.Constructor = &m
+		case "event":
+			abi.Events = append(abi.Events, newEvent(e.Name, inputs, e.Anonymous))
+		}
+	}
+	return &abi, nil
+}
+
+func newArguments(args []jsonArgument) (Arguments, error) {
+	out := make(Arguments, len(args))
+	for i, a := range args {
+		t, err := newArgumentType(a)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = Argument{Name: a.Name, Type: t, Indexed: a.Indexed}
+	}
+	return out, nil
+}
+
+// newArgumentType returns the type of a, whose tuples are given by their
+// components, e.g. "tuple[]".
+func newArgumentType(a jsonArgument) (Type, error) {
+	if !strings.HasPrefix(a.Type, "tuple") {
+		return NewType(a.Type)
+	}
+
+	tuple := Type{Kind: TupleKind}
+	for _, c := range a.Components {
+		t, err := newArgumentType(c)
+		if err != nil {
+			return Type{}, err
+		}
+		tuple.Components = append(tuple.Components, t)
+		tuple.ComponentNames = append(tuple.ComponentNames, c.Name)
+	}
+
+	t, rest, err := parseArraySuffix(tuple, a.Type[len("tuple"):])
+	if err != nil {
+		return Type{}, err
+	}
+	if rest != "" {
+		return Type{}, fmt.Errorf("abi: invalid type %q", a.Type)
+	}
+	return t, nil
+}
+
+// Method returns the method named name, or with the signature name for
+// overloaded methods.
+func (a *ABI) Method(name string) (*Method, error) {
+	var found *Method
+	for i := range a.Methods {
+		m := &a.Methods[i]
+		if m.Sig == name {
+			return m, nil
+		}
+		if m.Name == name {
+			if found != nil {
+				return nil, ErrAmbiguous
+			}
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return found, nil
+}
+
+// MethodByID returns the method with the selector id, the first 4 bytes of
+// call data.
+func (a *ABI) MethodByID(id []byte) (*Method, error) {
+	if len(id) < 4 {
+		return nil, ErrNotFound
+	}
+	for i := range a.Methods {
+		if string(a.Methods[i].ID) == string(id[:4]) {
+			return &a.Methods[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Event returns the event named name, or with the signature name for
+// overloaded events.
+func (a *ABI) Event(name string) (*Event, error) {
+	var found *Event
+	for i := range a.Events {
+		e := &a.Events[i]
+		if e.Sig == name {
+			return e, nil
+		}
+		if e.Name == name {
+			if found != nil {
+				return nil, ErrAmbiguous
+			}
+			found = e
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return found, nil
+}
+
+// EventByID returns the event with the topic id, the first topic of its
+// logs.
+func (a *ABI) EventByID(id []byte) (*Event, error) {
+	for i := range a.Events {
+		if string(a.Events[i].ID) == string(id) {
+			return &a.Events[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Pack encodes a call of the method name with args.
+func (a *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Pack(args...)
+}
+
+// Unpack decodes the output of a call of the method name.
+func (a *ABI) Unpack(name string, output []byte) ([]interface{}, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Unpack(output)
+}
+
+var (
+	errorSelector = Selector("Error(string)")
+	panicSelector = Selector("Panic(uint256)")
+)
+
+// panicReasons are the reasons of the compiler generated Panic(uint256)
+// codes.
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to invalid internal function",
+}
+
+// DecodeRevert decodes the reason of revert data: the message of an
+// Error(string), or a description of a Panic(uint256). ErrNotRevert is
+// returned for other data, e.g. custom errors.
+func DecodeRevert(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", ErrNotRevert
+	}
+	switch string(data[:4]) {
+	case string(errorSelector):
+		v, err := Decode([]Type{{Kind: StringKind}}, data[4:])
+		if err != nil {
+			return "", err
+		}
+		return v[0].(string), nil
+	case string(panicSelector):
+		v, err := Decode([]Type{{Kind: UintKind, Size: 256}}, data[4:])
+		if err != nil {
+			return "", err
+		}
+		code := v[0].(*big.Int)
+		if reason, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+			return fmt.Sprintf("panic: %s (0x%x)", reason, code), nil
+		}
+		return fmt.Sprintf("panic: 0x%x", code), nil
+	}
+	return "", ErrNotRevert
+}
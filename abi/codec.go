@@ -0,0 +1,410 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// ErrInvalidData is returned when decoding data that is not a valid
+// encoding of the expected types.
+var ErrInvalidData = errors.New("abi: invalid data")
+
+// tt256 is 2^256, to convert negative integers to and from two's
+// complement.
+var tt256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// Encode encodes values as the types, e.g. the arguments of a call.
+//
+// Integers are given as *big.Int, any Go integer type or a decimal or 0x
+// hex string. Addresses are base58 or hex strings, or 20 or 21 bytes
+// slices. bool is a bool, string a string, bytes and bytesN []byte (or
+// byte arrays). Arrays and slices are Go slices or arrays, tuples are
+// []interface{} in component order or map[string]interface{} by component
+// name.
+func Encode(types []Type, values []interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("abi: %d values for %d types", len(values), len(types))
+	}
+
+	headLen := 0
+	for _, t := range types {
+		headLen += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		enc, err := encode(t, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("abi: argument %d (%s): %w", i, t, err)
+		}
+		if t.IsDynamic() {
+			head = append(head, uintWord(uint64(headLen+len(tail)))...)
+			tail = append(tail, enc...)
+		} else {
+			head = append(head, enc...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+func encode(t Type, v interface{}) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		n, err := toBig(v)
+		if err != nil {
+			return nil, err
+		}
+		return encodeInt(t, n)
+	case AddressKind:
+		return encodeAddress(v)
+	case BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %T as bool", v)
+		}
+		if b {
+			return uintWord(1), nil
+		}
+		return uintWord(0), nil
+	case FixedBytesKind:
+		b, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("%d bytes for %s", len(b), t)
+		}
+		return padRight(b), nil
+	case BytesKind:
+		b, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(uintWord(uint64(len(b))), padRight(b)...), nil
+	case StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %T as string", v)
+		}
+		return append(uintWord(uint64(len(s))), padRight([]byte(s))...), nil
+	case ArrayKind, SliceKind:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("cannot use %T as %s", v, t)
+		}
+		if t.Kind == ArrayKind && rv.Len() != t.Size {
+			return nil, fmt.Errorf("%d elements for %s", rv.Len(), t)
+		}
+		types := make([]Type, rv.Len())
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			types[i], values[i] = *t.Elem, rv.Index(i).Interface()
+		}
+		enc, err := Encode(types, values)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == SliceKind {
+			enc = append(uintWord(uint64(rv.Len())), enc...)
+		}
+		return enc, nil
+	case TupleKind:
+		values, err := tupleValues(t, v)
+		if err != nil {
+			return nil, err
+		}
+		return Encode(t.Components, values)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func encodeInt(t Type, n *big.Int) ([]byte, error) {
+	if t.Kind == UintKind {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return nil, fmt.Errorf("%s overflows %s", n, t)
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%s overflows %s", n, t)
+		}
+		if n.Sign() < 0 {
+			// Two's complement on 256 bits.
+			n = new(big.Int).Add(n, tt256)
+		}
+	}
+	word := make([]byte, 32)
+	b := n.Bytes()
+	copy(word[32-len(b):], b)
+	return word, nil
+}
+
+func encodeAddress(v interface{}) ([]byte, error) {
+	var b []byte
+	switch a := v.(type) {
+	case string:
+		addr, err := address.Decode(a)
+		if err != nil {
+			return nil, err
+		}
+		b = addr[1:]
+	case []byte:
+		switch {
+		case len(a) == address.Length && a[0] == address.Prefix:
+			b = a[1:]
+		case len(a) == 20:
+			b = a
+		default:
+			return nil, address.ErrInvalidAddress
+		}
+	default:
+		return nil, fmt.Errorf("cannot use %T as address", v)
+	}
+	word := make([]byte, 32)
+	copy(word[12:], b)
+	return word, nil
+}
+
+// tupleValues returns the values of the components of the tuple t in v.
+func tupleValues(t Type, v interface{}) ([]interface{}, error) {
+	switch tv := v.(type) {
+	case []interface{}:
+		if len(tv) != len(t.Components) {
+			return nil, fmt.Errorf("%d values for %s", len(tv), t)
+		}
+		return tv, nil
+	case map[string]interface{}:
+		if len(t.ComponentNames) != len(t.Components) {
+			return nil, fmt.Errorf("%s has no component names", t)
+		}
+		values := make([]interface{}, len(t.Components))
+		for i, name := range t.ComponentNames {
+			value, ok := tv[name]
+			if !ok {
+				return nil, fmt.Errorf("missing component %q", name)
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("cannot use %T as %s", v, t)
+}
+
+func toBig(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case *big.Int:
+		if n == nil {
+			return nil, errors.New("nil integer")
+		}
+		return n, nil
+	case big.Int:
+		return &n, nil
+	case string:
+		s, base := n, 10
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			s, base = s[2:], 16
+		}
+		i, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", n)
+		}
+		return i, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("cannot use %T as integer", v)
+}
+
+func toBytes(v interface{}) ([]byte, error) {
+	if b, ok := v.([]byte); ok {
+		return b, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return b, nil
+	}
+	return nil, fmt.Errorf("cannot use %T as bytes", v)
+}
+
+func uintWord(n uint64) []byte {
+	word := make([]byte, 32)
+	b := new(big.Int).SetUint64(n).Bytes()
+	copy(word[32-len(b):], b)
+	return word
+}
+
+// padRight pads b with zeros to a multiple of 32 bytes.
+func padRight(b []byte) []byte {
+	n := (len(b) + 31) / 32 * 32
+	out := make([]byte, n)
+	copy(out, b)
+	return out
+}
+
+// Decode decodes data encoded as the types, e.g. the output of a call.
+//
+// Integers are decoded as *big.Int, addresses as base58 strings, bool as
+// bool, string as string, bytes and bytesN as []byte, and arrays, slices and
+// tuples as []interface{}. Static values must be encoded exactly as Encode
+// would: integers out of the range of their type, addresses with high bytes
+// and bytesN or bool with nonzero padding are refused with ErrInvalidData.
+func Decode(types []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	pos := 0
+	for i, t := range types {
+		var (
+			v   interface{}
+			err error
+		)
+		if t.IsDynamic() {
+			var off int
+			if off, err = readLength(data, pos); err == nil {
+				v, err = decode(t, data[off:])
+			}
+		} else {
+			if pos > len(data) {
+				return nil, ErrInvalidData
+			}
+			v, err = decode(t, data[pos:])
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+		pos += t.headSize()
+	}
+	return values, nil
+}
+
+func decode(t Type, data []byte) (interface{}, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		w, err := word(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).SetBytes(w)
+		if t.Kind == IntKind && w[0]&0x80 != 0 {
+			n.Sub(n, tt256)
+		}
+		// The value must fit the N bits of the type, as encoded by
+		// encodeInt: uintN zero padded, intN sign extended.
+		if _, err := encodeInt(t, n); err != nil {
+			return nil, ErrInvalidData
+		}
+		return n, nil
+	case AddressKind:
+		w, err := word(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !zero(w[:12]) {
+			return nil, ErrInvalidData
+		}
+		return address.ToBase58(append([]byte{address.Prefix}, w[12:]...)), nil
+	case BoolKind:
+		w, err := word(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		// Only 0 and 1 are valid, with every higher byte zero.
+		if !zero(w[:31]) {
+			return nil, ErrInvalidData
+		}
+		switch w[31] {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+		return nil, ErrInvalidData
+	case FixedBytesKind:
+		w, err := word(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !zero(w[t.Size:]) {
+			return nil, ErrInvalidData
+		}
+		return append([]byte(nil), w[:t.Size]...), nil
+	case BytesKind, StringKind:
+		n, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if n > len(data)-32 {
+			return nil, ErrInvalidData
+		}
+		if t.Kind == StringKind {
+			return string(data[32 : 32+n]), nil
+		}
+		return append([]byte(nil), data[32:32+n]...), nil
+	case ArrayKind, SliceKind:
+		n, body := t.Size, data
+		if t.Kind == SliceKind {
+			var err error
+			if n, err = readLength(data, 0); err != nil {
+				return nil, err
+			}
+			// Each element takes at least a word: bound n by the data.
+			if n > len(data)/32 {
+				return nil, ErrInvalidData
+			}
+			body = data[32:]
+		}
+		types := make([]Type, n)
+		for i := range types {
+			types[i] = *t.Elem
+		}
+		return Decode(types, body)
+	case TupleKind:
+		return Decode(t.Components, data)
+	}
+	return nil, fmt.Errorf("abi: unsupported type %s", t)
+}
+
+// zero reports whether every byte of b is zero, as the padding of the
+// static types must be.
+func zero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// word returns the 32 bytes word at pos of data.
+func word(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos+32 > len(data) {
+		return nil, ErrInvalidData
+	}
+	return data[pos : pos+32], nil
+}
+
+// readLength reads the word at pos of data as an offset or length within
+// data.
+func readLength(data []byte, pos int) (int, error) {
+	w, err := word(data, pos)
+	if err != nil {
+		return 0, err
+	}
+	n := new(big.Int).SetBytes(w)
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, ErrInvalidData
+	}
+	return int(n.Int64()), nil
+}
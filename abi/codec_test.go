@@ -0,0 +1,230 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// words concatenates hex encoded words, ignoring white space.
+func words(s string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Examples of the Solidity ABI specification.
+// https://docs.soliditylang.org/en/latest/abi-spec.html#examples
+var specVectors = []struct {
+	sig  string
+	args []interface{}
+	want string
+}{
+	{
+		"baz(uint32,bool)",
+		[]interface{}{69, true},
+		`cdcd77c0
+		0000000000000000000000000000000000000000000000000000000000000045
+		0000000000000000000000000000000000000000000000000000000000000001`,
+	},
+	{
+		"sam(bytes,bool,uint256[])",
+		[]interface{}{[]byte("dave"), true, []int{1, 2, 3}},
+		`a5643bf2
+		0000000000000000000000000000000000000000000000000000000000000060
+		0000000000000000000000000000000000000000000000000000000000000001
+		00000000000000000000000000000000000000000000000000000000000000a0
+		0000000000000000000000000000000000000000000000000000000000000004
+		6461766500000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000003`,
+	},
+	{
+		"f(uint256,uint32[],bytes10,bytes)",
+		[]interface{}{"0x123", []interface{}{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+		`8be65246
+		0000000000000000000000000000000000000000000000000000000000000123
+		0000000000000000000000000000000000000000000000000000000000000080
+		3132333435363738393000000000000000000000000000000000000000000000
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000456
+		0000000000000000000000000000000000000000000000000000000000000789
+		000000000000000000000000000000000000000000000000000000000000000d
+		48656c6c6f2c20776f726c642100000000000000000000000000000000000000`,
+	},
+	{
+		"g(uint256[][],string[])",
+		[]interface{}{[][]int{{1, 2}, {3}}, []string{"one", "two", "three"}},
+		`2289b18c
+		0000000000000000000000000000000000000000000000000000000000000040
+		0000000000000000000000000000000000000000000000000000000000000140
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000040
+		00000000000000000000000000000000000000000000000000000000000000a0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000060
+		00000000000000000000000000000000000000000000000000000000000000a0
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000003
+		6f6e650000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000003
+		74776f0000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000005
+		7468726565000000000000000000000000000000000000000000000000000000`,
+	},
+}
+
+func TestPackSpecVectors(t *testing.T) {
+	for _, v := range specVectors {
+		m, err := ParseMethod(v.sig)
+		if err != nil {
+			t.Fatalf("ParseMethod(%s): %v", v.sig, err)
+		}
+		got, err := m.Pack(v.args...)
+		if err != nil {
+			t.Errorf("%s: Pack: %v", v.sig, err)
+			continue
+		}
+		if want := words(v.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Pack =\n%x\nwant\n%x", v.sig, got, want)
+		}
+	}
+}
+
+// The decoded values are packed back to the same data.
+func TestUnpackSpecVectors(t *testing.T) {
+	for _, v := range specVectors {
+		m, _ := ParseMethod(v.sig)
+		data := words(v.want)
+
+		values, err := m.Inputs.Unpack(data[4:])
+		if err != nil {
+			t.Errorf("%s: Unpack: %v", v.sig, err)
+			continue
+		}
+		again, err := m.Pack(values...)
+		if err != nil || !reflect.DeepEqual(again, data) {
+			t.Errorf("%s: Pack(Unpack) = %x, %v", v.sig, again, err)
+		}
+	}
+}
+
+func TestDecodeValues(t *testing.T) {
+	tests := []struct {
+		typ  string
+		data string
+		want interface{}
+	}{
+		{"uint256", "00000000000000000000000000000000000000000000000000000000000f4240", big.NewInt(1000000)},
+		{"int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff85", big.NewInt(-123)},
+		{"int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", big.NewInt(-128)},
+		{"int8", "000000000000000000000000000000000000000000000000000000000000007f", big.NewInt(127)},
+		{"uint8", "00000000000000000000000000000000000000000000000000000000000000ff", big.NewInt(255)},
+		{"uint256", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))},
+		{"bool", "0000000000000000000000000000000000000000000000000000000000000000", false},
+		{"bool", "0000000000000000000000000000000000000000000000000000000000000001", true},
+		{"address", "000000000000000000000000a614f803b6fd780986a42c78ec9c7f77e6ded13c", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		{"bytes4", "a9059cbb00000000000000000000000000000000000000000000000000000000", []byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{"string", `0000000000000000000000000000000000000000000000000000000000000020
+			0000000000000000000000000000000000000000000000000000000000000004
+			5553445400000000000000000000000000000000000000000000000000000000`, "USDT"},
+	}
+	for _, tt := range tests {
+		typ, err := NewType(tt.typ)
+		if err != nil {
+			t.Fatalf("NewType(%s): %v", tt.typ, err)
+		}
+		got, err := Decode([]Type{typ}, words(tt.data))
+		if err != nil {
+			t.Errorf("Decode %s: %v", tt.typ, err)
+			continue
+		}
+		if !reflect.DeepEqual(got[0], tt.want) {
+			t.Errorf("Decode %s = %#v, want %#v", tt.typ, got[0], tt.want)
+		}
+	}
+}
+
+func TestDecodeInvalidData(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		data string
+	}{
+		{"bool 2", "bool", "0000000000000000000000000000000000000000000000000000000000000002"},
+		{"bool with a high byte", "bool", "0000000000000000000000000000000000000000000000010000000000000001"},
+		{"bool with the top byte", "bool", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"short word", "uint256", "0001"},
+		{"uint8 256", "uint8", "0000000000000000000000000000000000000000000000000000000000000100"},
+		{"uint32 with a high byte", "uint32", "0000000000000000000000000000000000000000000000000000000100000001"},
+		{"int8 128", "int8", "0000000000000000000000000000000000000000000000000000000000000080"},
+		{"int8 -129", "int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"int8 not sign extended", "int8", "00000000000000000000000000000000000000000000000000000000000000ff"},
+		{"address with a high byte", "address", "000000000000000000000001a614f803b6fd780986a42c78ec9c7f77e6ded13c"},
+		{"bytes4 with nonzero padding", "bytes4", "a9059cbb00000000000000000000000000000000000000000000000000000001"},
+		{"string offset past the end", "string", "00000000000000000000000000000000000000000000000000000000000000ff"},
+		{"string length past the end", "string", `0000000000000000000000000000000000000000000000000000000000000020
+			0000000000000000000000000000000000000000000000000000000000000040
+			5553445400000000000000000000000000000000000000000000000000000000`},
+	}
+	for _, tt := range tests {
+		typ, _ := NewType(tt.typ)
+		if _, err := Decode([]Type{typ}, words(tt.data)); err == nil {
+			t.Errorf("%s: Decode succeeded", tt.name)
+		}
+	}
+}
+
+func TestEncodeOverflow(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value interface{}
+	}{
+		{"uint8", 256},
+		{"uint256", -1},
+		{"int8", 128},
+		{"int8", -129},
+		{"uint256", new(big.Int).Lsh(big.NewInt(1), 256)},
+	}
+	for _, tt := range tests {
+		typ, _ := NewType(tt.typ)
+		if _, err := Encode([]Type{typ}, []interface{}{tt.value}); err == nil {
+			t.Errorf("Encode(%s, %v) succeeded", tt.typ, tt.value)
+		}
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+		err  error
+	}{
+		{`08c379a0
+			0000000000000000000000000000000000000000000000000000000000000020
+			0000000000000000000000000000000000000000000000000000000000000004
+			6e6f706500000000000000000000000000000000000000000000000000000000`, "nope", nil},
+		{`4e487b71
+			0000000000000000000000000000000000000000000000000000000000000011`, "panic: arithmetic overflow or underflow (0x11)", nil},
+		{"", "", ErrNotRevert},
+		{"a9059cbb", "", ErrNotRevert},
+	}
+	for _, tt := range tests {
+		got, err := DecodeRevert(words(tt.data))
+		if got != tt.want || err != tt.err {
+			t.Errorf("DecodeRevert(%s) = %q, %v, want %q, %v", tt.data, got, err, tt.want, tt.err)
+		}
+	}
+}
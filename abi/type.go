@@ -0,0 +1,227 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an ABI type.
+type Kind int
+
+// ABI type kinds.
+const (
+	UintKind Kind = iota
+	IntKind
+	AddressKind
+	BoolKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	ArrayKind
+	SliceKind
+	TupleKind
+)
+
+// Type is a Solidity ABI type.
+type Type struct {
+	Kind Kind
+	// Size is the bit size of integers, the byte size of fixed bytes and the
+	// length of fixed arrays.
+	Size int
+	// Elem is the element type of arrays and slices.
+	Elem *Type
+	// Components are the types of the tuple fields, and ComponentNames
+	// their names when known.
+	Components     []Type
+	ComponentNames []string
+}
+
+// NewType parses a canonical type such as "uint256", "address[]",
+// "bytes32[2]" or "(address,uint256)[]". The TRON trcToken type is an
+// alias of uint256.
+func NewType(s string) (Type, error) {
+	t, rest, err := parseType(strings.TrimSpace(s))
+	if err != nil {
+		return Type{}, err
+	}
+	if rest != "" {
+		return Type{}, fmt.Errorf("abi: invalid type %q", s)
+	}
+	return t, nil
+}
+
+// parseType parses the type at the start of s and returns the rest of s.
+func parseType(s string) (Type, string, error) {
+	var t Type
+	if strings.HasPrefix(s, "(") {
+		comps, rest, err := parseTypeList(s[1:])
+		if err != nil {
+			return Type{}, "", err
+		}
+		t, s = Type{Kind: TupleKind, Components: comps}, rest
+	} else {
+		end := strings.IndexAny(s, "[,)")
+		if end < 0 {
+			end = len(s)
+		}
+		elem, err := newElementaryType(s[:end])
+		if err != nil {
+			return Type{}, "", err
+		}
+		t, s = elem, s[end:]
+	}
+
+	return parseArraySuffix(t, s)
+}
+
+// parseArraySuffix applies the array suffixes at the start of s to t, the
+// innermost first: uint256[2][] is a slice of uint256[2]. It returns the
+// rest of s.
+func parseArraySuffix(t Type, s string) (Type, string, error) {
+	for strings.HasPrefix(s, "[") {
+		end := strings.Index(s, "]")
+		if end < 0 {
+			return Type{}, "", fmt.Errorf("abi: unterminated array in %q", s)
+		}
+		elem := t
+		if end == 1 {
+			t = Type{Kind: SliceKind, Elem: &elem}
+		} else {
+			n, err := strconv.Atoi(s[1:end])
+			if err != nil || n <= 0 {
+				return Type{}, "", fmt.Errorf("abi: invalid array length %q", s[1:end])
+			}
+			t = Type{Kind: ArrayKind, Size: n, Elem: &elem}
+		}
+		s = s[end+1:]
+	}
+	return t, s, nil
+}
+
+// parseTypeList parses comma separated types up to the closing parenthesis
+// and returns the rest of s after it.
+func parseTypeList(s string) ([]Type, string, error) {
+	var types []Type
+	if strings.HasPrefix(s, ")") {
+		return types, s[1:], nil
+	}
+	for {
+		t, rest, err := parseType(strings.TrimLeft(s, " "))
+		if err != nil {
+			return nil, "", err
+		}
+		types = append(types, t)
+		rest = strings.TrimLeft(rest, " ")
+		switch {
+		case strings.HasPrefix(rest, ","):
+			s = rest[1:]
+		case strings.HasPrefix(rest, ")"):
+			return types, rest[1:], nil
+		default:
+			return nil, "", fmt.Errorf("abi: unterminated tuple in %q", s)
+		}
+	}
+}
+
+func newElementaryType(s string) (Type, error) {
+	switch {
+	case s == "address":
+		return Type{Kind: AddressKind, Size: 20}, nil
+	case s == "bool":
+		return Type{Kind: BoolKind}, nil
+	case s == "string":
+		return Type{Kind: StringKind}, nil
+	case s == "bytes":
+		return Type{Kind: BytesKind}, nil
+	case s == "trcToken":
+		return Type{Kind: UintKind, Size: 256}, nil
+	case strings.HasPrefix(s, "bytes"):
+		n, err := strconv.Atoi(s[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return Type{}, fmt.Errorf("abi: invalid type %q", s)
+		}
+		return Type{Kind: FixedBytesKind, Size: n}, nil
+	case strings.HasPrefix(s, "uint"), strings.HasPrefix(s, "int"):
+		kind, digits := IntKind, strings.TrimPrefix(s, "int")
+		if strings.HasPrefix(s, "uint") {
+			kind, digits = UintKind, strings.TrimPrefix(s, "uint")
+		}
+		if digits == "" {
+			return Type{Kind: kind, Size: 256}, nil
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil || n < 8 || n > 256 || n%8 != 0 {
+			return Type{}, fmt.Errorf("abi: invalid type %q", s)
+		}
+		return Type{Kind: kind, Size: n}, nil
+	}
+	return Type{}, fmt.Errorf("abi: unsupported type %q", s)
+}
+
+// String returns the canonical form of t, as used in signatures.
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return "uint" + strconv.Itoa(t.Size)
+	case IntKind:
+		return "int" + strconv.Itoa(t.Size)
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return "bytes" + strconv.Itoa(t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case ArrayKind:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case TupleKind:
+		parts := make([]string, len(t.Components))
+		for i, c := range t.Components {
+			parts[i] = c.String()
+		}
+		return "(" + strings.Join(parts, ",") + ")"
+	}
+	return "?"
+}
+
+// IsDynamic reports whether the encoding of t has a variable length, in
+// which case it is encoded after the static part, at an offset.
+func (t Type) IsDynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.IsDynamic()
+	case TupleKind:
+		for _, c := range t.Components {
+			if c.IsDynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize returns the size of t in the static part of an encoding.
+func (t Type) headSize() int {
+	if t.IsDynamic() {
+		return 32
+	}
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		n := 0
+		for _, c := range t.Components {
+			n += c.headSize()
+		}
+		return n
+	}
+	return 32
+}
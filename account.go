@@ -0,0 +1,72 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UnmarshalJSON decodes an account, accepting latest_operation_time as well
+// as the misspelled latest_opration_time the node returns.
+func (a *Account) UnmarshalJSON(b []byte) error {
+	type account Account
+	var v struct {
+		account
+		LatestOperationTime *int64 `json:"latest_operation_time"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*a = Account(v.account)
+	if v.LatestOperationTime != nil && a.LatestOperationTime == 0 {
+		a.LatestOperationTime = *v.LatestOperationTime
+	}
+	return nil
+}
+
+// Name returns the account name, hex decoded unless the account was queried
+// with visible addresses, in which case it is already plain text.
+func (a *Account) Name() string {
+	return bytesText(a.AccountName, a.Visible)
+}
+
+// IssuedAssetName returns the name of the TRC10 asset issued by the account,
+// hex decoded unless the account was queried with visible addresses.
+func (a *Account) IssuedAssetName() string {
+	return bytesText(a.AssetIssuedName, a.Visible)
+}
+
+// CreatedAt returns the creation time of the account.
+func (a *Account) CreatedAt() time.Time {
+	return msToTime(a.CreateTime)
+}
+
+// LatestOperationAt returns the time of the latest operation of the account.
+func (a *Account) LatestOperationAt() time.Time {
+	return msToTime(a.LatestOperationTime)
+}
+
+// LatestWithdrawAt returns the time of the latest reward withdrawal.
+func (a *Account) LatestWithdrawAt() time.Time {
+	return msToTime(a.LatestWithdrawTime)
+}
+
+// LatestConsumeAt returns the time bandwidth was last consumed.
+func (a *Account) LatestConsumeAt() time.Time {
+	return msToTime(a.LatestConsumeTime)
+}
+
+// LatestConsumeFreeAt returns the time free bandwidth was last consumed.
+func (a *Account) LatestConsumeFreeAt() time.Time {
+	return msToTime(a.LatestConsumeFreeTime)
+}
+
+// ExpiresAt returns the time a stake 1.0 frozen balance can be unfrozen.
+func (f *Frozen) ExpiresAt() time.Time {
+	return msToTime(f.ExpireTime)
+}
+
+// ExpiresAt returns the time an unstaked balance can be withdrawn.
+func (u *UnfrozenV2) ExpiresAt() time.Time {
+	return msToTime(u.UnfreezeExpireTime)
+}
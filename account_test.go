@@ -0,0 +1,194 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// accountJSON and visibleAccountJSON are the /wallet/getaccount responses
+// for the same account, queried with visible false and true: addresses are
+// base58 and the name fields plain text in the visible one.
+const (
+	accountJSON = `{
+	"account_name": "63616665",
+	"address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a",
+	"balance": 123456789,
+	"create_time": 1600000000000,
+	"latest_opration_time": 1681000000000,
+	"free_net_usage": 268,
+	"latest_consume_free_time": 1681000003000,
+	"net_window_size": 28800000,
+	"net_window_optimized": true,
+	"account_resource": {
+		"energy_window_size": 28800000,
+		"latest_consume_time_for_energy": 1681000000000,
+		"energy_window_optimized": true
+	},
+	"owner_permission": {
+		"permission_name": "owner",
+		"threshold": 1,
+		"keys": [{"address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a", "weight": 1}]
+	},
+	"active_permission": [{
+		"type": "Active",
+		"id": 2,
+		"permission_name": "active",
+		"threshold": 2,
+		"operations": "7fff1fc0033efb0f000000000000000000000000000000000000000000000000",
+		"keys": [
+			{"address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a", "weight": 1},
+			{"address": "4141d16df1f417a16410d0a1ee4d360d3f2319ad67", "weight": 1}
+		]
+	}],
+	"frozenV2": [{"amount": 5000000}, {"type": "ENERGY", "amount": 7000000}, {"type": "TRON_POWER"}],
+	"unfrozenV2": [{"type": "ENERGY", "unfreeze_amount": 1000000, "unfreeze_expire_time": 1682000000000}],
+	"asset_issued_name": "4361666520546f6b656e",
+	"asset_issued_ID": "31303032303030",
+	"asset_optimized": true,
+	"assetV2": [{"key": "1002000", "value": 42}],
+	"free_asset_net_usageV2": [{"key": "1002000", "value": 0}]
+}`
+
+	visibleAccountJSON = `{
+	"account_name": "cafe",
+	"address": "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH",
+	"balance": 123456789,
+	"create_time": 1600000000000,
+	"latest_opration_time": 1681000000000,
+	"free_net_usage": 268,
+	"latest_consume_free_time": 1681000003000,
+	"net_window_size": 28800000,
+	"net_window_optimized": true,
+	"account_resource": {
+		"energy_window_size": 28800000,
+		"latest_consume_time_for_energy": 1681000000000,
+		"energy_window_optimized": true
+	},
+	"owner_permission": {
+		"permission_name": "owner",
+		"threshold": 1,
+		"keys": [{"address": "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", "weight": 1}]
+	},
+	"active_permission": [{
+		"type": "Active",
+		"id": 2,
+		"permission_name": "active",
+		"threshold": 2,
+		"operations": "7fff1fc0033efb0f000000000000000000000000000000000000000000000000",
+		"keys": [
+			{"address": "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", "weight": 1},
+			{"address": "TFyDowe63AUWrfB3xpJdaYXvxWjqH6RHHL", "weight": 1}
+		]
+	}],
+	"frozenV2": [{"amount": 5000000}, {"type": "ENERGY", "amount": 7000000}, {"type": "TRON_POWER"}],
+	"unfrozenV2": [{"type": "ENERGY", "unfreeze_amount": 1000000, "unfreeze_expire_time": 1682000000000}],
+	"asset_issued_name": "Cafe Token",
+	"asset_issued_ID": "1002000",
+	"asset_optimized": true,
+	"assetV2": [{"key": "1002000", "value": 42}],
+	"free_asset_net_usageV2": [{"key": "1002000", "value": 0}]
+}`
+)
+
+func TestAccountUnmarshal(t *testing.T) {
+	for _, visible := range []bool{false, true} {
+		data := accountJSON
+		if visible {
+			data = visibleAccountJSON
+		}
+		var a Account
+		if err := json.Unmarshal([]byte(data), &a); err != nil {
+			t.Fatal(err)
+		}
+		a.Visible = visible
+
+		tests := []struct {
+			name      string
+			got, want interface{}
+		}{
+			{"Name", a.Name(), "cafe"},
+			{"IssuedAssetName", a.IssuedAssetName(), "Cafe Token"},
+			{"Balance", a.Balance, Sun(123456789)},
+			{"CreatedAt", a.CreatedAt().UTC(), time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)},
+			{"LatestOperationTime", a.LatestOperationTime, int64(1681000000000)},
+			{"FreeNetUsage", a.FreeNetUsage, int64(268)},
+			{"FrozenV2 bandwidth", a.FrozenV2[0], FrozenV2{Amount: 5000000}},
+			{"FrozenV2 energy", a.FrozenV2[1], FrozenV2{Type: "ENERGY", Amount: 7000000}},
+			{"UnfrozenV2 expiration", a.UnfrozenV2[0].ExpiresAt().UnixNano(), int64(1682000000000) * int64(time.Millisecond)},
+			{"owner threshold", a.OwnerPermission.Threshold, int64(1)},
+			{"active id", a.ActivePermission[0].Id, 2},
+			{"active keys", len(a.ActivePermission[0].Keys), 2},
+			{"assetV2", a.AssetV2[0], Asset{Key: "1002000", Value: 42}},
+		}
+		for _, tt := range tests {
+			if tt.got != tt.want {
+				t.Errorf("visible %v: %s = %v, want %v", visible, tt.name, tt.got, tt.want)
+			}
+		}
+
+		perm, err := a.Permission(2)
+		if err != nil || perm.PermissionName != "active" {
+			t.Errorf("visible %v: Permission(2) = %v, %v", visible, perm, err)
+		}
+		if !perm.Allows(TransferContract) {
+			t.Errorf("visible %v: active permission does not allow TransferContract", visible)
+		}
+		if _, err := a.Permission(3); err != ErrUnknownPermission {
+			t.Errorf("visible %v: Permission(3) error = %v, want ErrUnknownPermission", visible, err)
+		}
+	}
+}
+
+// GetAccount decodes the name fields after the visible flag of the request:
+// a plain text name which happens to be hex is left alone.
+func TestGetAccountVisible(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Visible bool `json:"visible"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Visible {
+			fmt.Fprint(w, visibleAccountJSON)
+		} else {
+			fmt.Fprint(w, accountJSON)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	for _, visible := range []bool{false, true} {
+		a, err := c.GetAccount("TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", visible, Latest)
+		if err != nil {
+			t.Fatalf("GetAccount: %v", err)
+		}
+		if a.Visible != visible || a.Name() != "cafe" {
+			t.Errorf("GetAccount(visible %v) = %v, name %q, want name \"cafe\"", visible, a.Visible, a.Name())
+		}
+	}
+}
+
+func TestAccountLatestOperationTime(t *testing.T) {
+	tests := []struct {
+		json string
+		want int64
+	}{
+		{`{"latest_opration_time": 1}`, 1},
+		{`{"latest_operation_time": 2}`, 2},
+		// The misspelled field of the node wins.
+		{`{"latest_opration_time": 1, "latest_operation_time": 2}`, 1},
+		{`{}`, 0},
+	}
+	for _, tt := range tests {
+		var a Account
+		if err := json.Unmarshal([]byte(tt.json), &a); err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if a.LatestOperationTime != tt.want {
+			t.Errorf("%s: LatestOperationTime = %d, want %d", tt.json, a.LatestOperationTime, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package address implements the TRON address codec: the 21 bytes
+// address (0x41 prefix followed by the last 20 bytes of the Keccak-256 of
+// the public key) and its base58check and hex representations.
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Prefix is the first byte of every TRON mainnet/testnet address.
+const Prefix byte = 0x41
+
+// Length is the length in bytes of a decoded TRON address.
+const Length = 21
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidAddress is returned when a string is neither a base58check
+	// nor a hex encoded TRON address.
+	ErrInvalidAddress = errors.New("address: invalid TRON address")
+	// ErrChecksum is returned when the base58check checksum does not match.
+	ErrChecksum = errors.New("address: invalid base58check checksum")
+	// ErrInvalidPublicKey is returned when the public key is not a 65 bytes
+	// uncompressed secp256k1 key.
+	ErrInvalidPublicKey = errors.New("address: invalid uncompressed public key")
+)
+
+var bigRadix = big.NewInt(58)
+
+// EncodeBase58 encodes b using the bitcoin base58 alphabet.
+func EncodeBase58(b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, bigRadix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a bitcoin base58 string.
+func DecodeBase58(s string) ([]byte, error) {
+	x := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(alphabet, s[i])
+		if idx < 0 {
+			return nil, ErrInvalidAddress
+		}
+		x.Mul(x, bigRadix)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
+
+// EncodeBase58Check appends the 4 bytes double SHA-256 checksum to b and
+// encodes the result in base58.
+func EncodeBase58Check(b []byte) string {
+	return EncodeBase58(append(append([]byte{}, b...), checksum(b)...))
+}
+
+// DecodeBase58Check decodes s and verifies its 4 bytes checksum.
+func DecodeBase58Check(s string) ([]byte, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 5 {
+		return nil, ErrInvalidAddress
+	}
+
+	payload, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return nil, ErrChecksum
+	}
+	return payload, nil
+}
+
+func checksum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
+
+// Keccak256 returns the legacy Keccak-256 hash of the concatenation of data.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// FromPublicKey returns the 21 bytes address of a 65 bytes uncompressed
+// secp256k1 public key.
+func FromPublicKey(pub []byte) ([]byte, error) {
+	if len(pub) != 65 || pub[0] != 0x04 {
+		return nil, ErrInvalidPublicKey
+	}
+	hash := Keccak256(pub[1:])
+	return append([]byte{Prefix}, hash[len(hash)-20:]...), nil
+}
+
+// ToBase58 returns the base58check representation of a 21 bytes address.
+func ToBase58(addr []byte) string {
+	return EncodeBase58Check(addr)
+}
+
+// ToHex returns the hex representation of a 21 bytes address.
+func ToHex(addr []byte) string {
+	return hex.EncodeToString(addr)
+}
+
+// Decode decodes a TRON address given either in base58check ("T...") or in
+// hex ("41..." or "0x41...") and returns its 21 bytes form.
+func Decode(s string) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if len(s) == 2*Length || len(s) == 2*Length+2 {
+		b, err = hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	} else {
+		b, err = DecodeBase58Check(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != Length || b[0] != Prefix {
+		return nil, ErrInvalidAddress
+	}
+	return b, nil
+}
+
+// IsValid reports whether s is a well formed TRON address.
+func IsValid(s string) bool {
+	_, err := Decode(s)
+	return err == nil
+}
@@ -0,0 +1,220 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Sun is an amount of SUN, the smallest unit of TRX (1 TRX = 1000000 SUN).
+// Every TRX amount of the API is in SUN.
+type Sun int64
+
+// SunPerTRX is the number of SUN in one TRX.
+const SunPerTRX Sun = 1000000
+
+// trxDecimals is the number of decimals of TRX.
+const trxDecimals = 6
+
+var (
+	// ErrAmountOverflow is returned when an arithmetic operation or a parsed
+	// amount overflows its type.
+	ErrAmountOverflow = errors.New("amount overflow")
+	// ErrInvalidAmount is returned when parsing a malformed decimal amount,
+	// or one with more decimals than allowed.
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+// TRX returns n TRX in SUN.
+func TRX(n int64) (Sun, error) {
+	return Sun(n).Mul(int64(SunPerTRX))
+}
+
+// Add returns s + o, or ErrAmountOverflow.
+func (s Sun) Add(o Sun) (Sun, error) {
+	if (o > 0 && s > math.MaxInt64-o) || (o < 0 && s < math.MinInt64-o) {
+		return 0, ErrAmountOverflow
+	}
+	return s + o, nil
+}
+
+// Sub returns s - o, or ErrAmountOverflow.
+func (s Sun) Sub(o Sun) (Sun, error) {
+	if (o < 0 && s > math.MaxInt64+o) || (o > 0 && s < math.MinInt64+o) {
+		return 0, ErrAmountOverflow
+	}
+	return s - o, nil
+}
+
+// Mul returns s * n, or ErrAmountOverflow.
+func (s Sun) Mul(n int64) (Sun, error) {
+	if s == 0 || n == 0 {
+		return 0, nil
+	}
+	r := int64(s) * n
+	if r/n != int64(s) || (s == -1 && n == math.MinInt64) || (n == -1 && s == math.MinInt64) {
+		return 0, ErrAmountOverflow
+	}
+	return Sun(r), nil
+}
+
+// ParseTRX parses a decimal TRX amount such as "1.5" and returns it in SUN.
+// At most 6 decimals are allowed.
+func ParseTRX(s string) (Sun, error) {
+	v, err := parseDecimal(s, trxDecimals)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+	return Sun(v.Int64()), nil
+}
+
+// TRXString returns s as a decimal TRX amount, e.g. "1.5", without trailing
+// zeros.
+func (s Sun) TRXString() string {
+	return formatDecimal(big.NewInt(int64(s)), trxDecimals)
+}
+
+// String returns s in TRX with its unit, e.g. "1.5 TRX".
+func (s Sun) String() string {
+	return s.TRXString() + " TRX"
+}
+
+// UnmarshalJSON decodes s from a JSON number or a string of digits.
+func (s *Sun) UnmarshalJSON(b []byte) error {
+	str := strings.Trim(string(b), `"`)
+	if str == "null" || str == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid SUN amount %s", b)
+	}
+	*s = Sun(n)
+	return nil
+}
+
+// MarshalJSON encodes s as a JSON number of SUN, as the node expects.
+func (s Sun) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(s))
+}
+
+// TokenAmount is an amount of a token with arbitrary decimals, such as
+// TRC10 (up to 6 decimals) and TRC20 (up to 77 decimals, uint256) tokens.
+// Value is in the smallest unit of the token.
+type TokenAmount struct {
+	Value    *big.Int
+	Decimals int
+}
+
+// NewTokenAmount returns value smallest units of a token with decimals.
+func NewTokenAmount(value *big.Int, decimals int) TokenAmount {
+	return TokenAmount{Value: new(big.Int).Set(value), Decimals: decimals}
+}
+
+// ParseTokenAmount parses a decimal amount such as "12.34" of a token with
+// decimals.
+func ParseTokenAmount(s string, decimals int) (TokenAmount, error) {
+	v, err := parseDecimal(s, decimals)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return TokenAmount{Value: v, Decimals: decimals}, nil
+}
+
+// String returns a as a decimal amount without trailing zeros.
+func (a TokenAmount) String() string {
+	if a.Value == nil {
+		return "0"
+	}
+	return formatDecimal(a.Value, a.Decimals)
+}
+
+// MarshalJSON encodes a as a decimal string, e.g. "12.34".
+func (a TokenAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes a from a decimal string or number, e.g. "12.34". The
+// amount keeps the decimals already set in a, or takes the decimals of the
+// string when it has more.
+func (a *TokenAmount) UnmarshalJSON(b []byte) error {
+	str := strings.Trim(string(b), `"`)
+	if str == "null" || str == "" {
+		return nil
+	}
+
+	decimals := a.Decimals
+	if i := strings.Index(str, "."); i >= 0 && len(str)-i-1 > decimals {
+		decimals = len(str) - i - 1
+	}
+	v, err := parseDecimal(str, decimals)
+	if err != nil {
+		return fmt.Errorf("invalid token amount %s", b)
+	}
+	a.Value, a.Decimals = v, decimals
+	return nil
+}
+
+// parseDecimal parses the decimal s scaled by 10^decimals.
+func parseDecimal(s string, decimals int) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg, s = s[0] == '-', s[1:]
+	}
+
+	// A single sign, and digits after the point if any: not "-+1" nor "1.".
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 || (len(parts) == 1 && parts[0] == "") || (len(parts) == 2 && parts[1] == "") {
+		return nil, ErrInvalidAmount
+	}
+
+	frac := ""
+	if len(parts) == 2 {
+		frac = parts[1]
+	}
+	if len(frac) > decimals {
+		return nil, ErrInvalidAmount
+	}
+
+	digits := parts[0] + frac + strings.Repeat("0", decimals-len(frac))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, ErrInvalidAmount
+		}
+	}
+
+	v, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, ErrInvalidAmount
+	}
+	if neg {
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+// formatDecimal formats v scaled down by 10^decimals.
+func formatDecimal(v *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	intPart, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	s := intPart
+	if frac != "" {
+		s += "." + frac
+	}
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParseTRX(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Sun
+		err  error
+	}{
+		{"1", 1000000, nil},
+		{"1.5", 1500000, nil},
+		{"0.000001", 1, nil},
+		{".5", 500000, nil},
+		{"+2", 2000000, nil},
+		{"-1.5", -1500000, nil},
+		{" 3 ", 3000000, nil},
+		{"9223372036854.775807", math.MaxInt64, nil},
+		{"9223372036854.775808", 0, ErrAmountOverflow},
+		{"1.0000001", 0, ErrInvalidAmount},
+		{"1.", 0, ErrInvalidAmount},
+		{".", 0, ErrInvalidAmount},
+		{"", 0, ErrInvalidAmount},
+		{"-", 0, ErrInvalidAmount},
+		{"-+1", 0, ErrInvalidAmount},
+		{"--1", 0, ErrInvalidAmount},
+		{"1..2", 0, ErrInvalidAmount},
+		{"1e6", 0, ErrInvalidAmount},
+		{"abc", 0, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := ParseTRX(tt.s)
+		if got != tt.want || err != tt.err {
+			t.Errorf("ParseTRX(%q) = %d, %v, want %d, %v", tt.s, int64(got), err, int64(tt.want), tt.err)
+		}
+	}
+}
+
+func TestSunString(t *testing.T) {
+	tests := []struct {
+		s    Sun
+		want string
+	}{
+		{0, "0 TRX"},
+		{1, "0.000001 TRX"},
+		{1500000, "1.5 TRX"},
+		{-1500000, "-1.5 TRX"},
+		{100000000, "100 TRX"},
+		{math.MaxInt64, "9223372036854.775807 TRX"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("Sun(%d).String() = %q, want %q", int64(tt.s), got, tt.want)
+		}
+	}
+}
+
+func TestSunArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func() (Sun, error)
+		want Sun
+		err  error
+	}{
+		{"add", func() (Sun, error) { return Sun(1).Add(2) }, 3, nil},
+		{"add overflow", func() (Sun, error) { return Sun(math.MaxInt64).Add(1) }, 0, ErrAmountOverflow},
+		{"add underflow", func() (Sun, error) { return Sun(math.MinInt64).Add(-1) }, 0, ErrAmountOverflow},
+		{"sub", func() (Sun, error) { return Sun(1).Sub(2) }, -1, nil},
+		{"sub underflow", func() (Sun, error) { return Sun(math.MinInt64).Sub(1) }, 0, ErrAmountOverflow},
+		{"mul", func() (Sun, error) { return Sun(3).Mul(4) }, 12, nil},
+		{"mul overflow", func() (Sun, error) { return Sun(math.MaxInt64 / 2).Mul(3) }, 0, ErrAmountOverflow},
+		{"mul min by -1", func() (Sun, error) { return Sun(math.MinInt64).Mul(-1) }, 0, ErrAmountOverflow},
+		{"TRX", func() (Sun, error) { return TRX(2) }, 2000000, nil},
+		{"TRX overflow", func() (Sun, error) { return TRX(math.MaxInt64 / 1000) }, 0, ErrAmountOverflow},
+	}
+	for _, tt := range tests {
+		got, err := tt.op()
+		if got != tt.want || err != tt.err {
+			t.Errorf("%s = %d, %v, want %d, %v", tt.name, int64(got), err, int64(tt.want), tt.err)
+		}
+	}
+}
+
+func TestSunJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Sun
+		ok   bool
+	}{
+		{`123`, 123, true},
+		{`"123"`, 123, true},
+		{`null`, 0, true},
+		{`1.5`, 0, false},
+		{`"abc"`, 0, false},
+	}
+	for _, tt := range tests {
+		var s Sun
+		err := json.Unmarshal([]byte(tt.json), &s)
+		if (err == nil) != tt.ok || s != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v, want %d", tt.json, int64(s), err, int64(tt.want))
+		}
+	}
+
+	b, err := json.Marshal(Sun(1500000))
+	if err != nil || string(b) != "1500000" {
+		t.Errorf("Marshal = %s, %v, want 1500000", b, err)
+	}
+}
+
+func TestTokenAmount(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		s        string
+		decimals int
+		value    string
+		str      string
+		err      error
+	}{
+		{"12.34", 18, "12340000000000000000", "12.34", nil},
+		{"12.3400", 4, "123400", "12.34", nil},
+		{"0.000001", 6, "1", "0.000001", nil},
+		{"-0.5", 1, "-5", "-0.5", nil},
+		{"7", 0, "7", "7", nil},
+		{maxUint256.String(), 0, maxUint256.String(), maxUint256.String(), nil},
+		{"1.5", 0, "", "", ErrInvalidAmount},
+		{"0.0000001", 6, "", "", ErrInvalidAmount},
+		{"1.", 2, "", "", ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		a, err := ParseTokenAmount(tt.s, tt.decimals)
+		if err != tt.err {
+			t.Errorf("ParseTokenAmount(%q, %d) error = %v, want %v", tt.s, tt.decimals, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if a.Value.String() != tt.value || a.String() != tt.str {
+			t.Errorf("ParseTokenAmount(%q, %d) = %s (%s), want %s (%s)", tt.s, tt.decimals, a.Value, a, tt.value, tt.str)
+		}
+	}
+
+	if got := (TokenAmount{}).String(); got != "0" {
+		t.Errorf("zero TokenAmount String = %q, want 0", got)
+	}
+}
+
+func TestTokenAmountJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		decimals int
+		value    int64
+		wantDec  int
+		ok       bool
+	}{
+		// The decimals already set are kept.
+		{`"12.34"`, 4, 123400, 4, true},
+		// More decimals in the string than set.
+		{`"0.001"`, 0, 1, 3, true},
+		{`12`, 2, 1200, 2, true},
+		{`"1."`, 2, 0, 2, false},
+		{`"-+1"`, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		a := TokenAmount{Decimals: tt.decimals}
+		err := json.Unmarshal([]byte(tt.json), &a)
+		if (err == nil) != tt.ok {
+			t.Errorf("Unmarshal(%s) error = %v", tt.json, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if a.Value.Int64() != tt.value || a.Decimals != tt.wantDec {
+			t.Errorf("Unmarshal(%s) = %s with %d decimals, want %d with %d", tt.json, a.Value, a.Decimals, tt.value, tt.wantDec)
+		}
+	}
+
+	a, _ := ParseTokenAmount("12.34", 6)
+	b, err := json.Marshal(a)
+	if err != nil || string(b) != `"12.34"` {
+		t.Errorf("Marshal = %s, %v", b, err)
+	}
+	back := TokenAmount{Decimals: 6}
+	if err := json.Unmarshal(b, &back); err != nil || back.Value.Cmp(a.Value) != 0 {
+		t.Errorf("round trip = %v, %v, want %v", back, err, a)
+	}
+}
@@ -0,0 +1,246 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// ErrAssetNotFound is returned when the node has no TRC10 asset with the
+// requested id.
+var ErrAssetNotFound = errors.New("asset not found")
+
+// AssetIssue is a TRC10 asset, and the parameter of AssetIssueContract.
+// Name, Abbr, Description and Url are hex encoded unless queried with
+// visible addresses, see the Text methods. Amounts are in the smallest unit
+// of the asset, see Precision.
+type AssetIssue struct {
+	ID                      string              `json:"id,omitempty" pb:"41,string"`
+	OwnerAddress            string              `json:"owner_address,omitempty" pb:"1,address"`
+	Name                    string              `json:"name,omitempty" pb:"2,name"`
+	Abbr                    string              `json:"abbr,omitempty" pb:"3,name"`
+	TotalSupply             int64               `json:"total_supply,omitempty" pb:"4,varint"`
+	FrozenSupply            []AssetFrozenSupply `json:"frozen_supply,omitempty" pb:"5,message"`
+	TrxNum                  int32               `json:"trx_num,omitempty" pb:"6,varint"`
+	Precision               int32               `json:"precision,omitempty" pb:"7,varint"`
+	Num                     int32               `json:"num,omitempty" pb:"8,varint"`
+	StartTime               int64               `json:"start_time,omitempty" pb:"9,varint"`
+	EndTime                 int64               `json:"end_time,omitempty" pb:"10,varint"`
+	Order                   int64               `json:"order,omitempty" pb:"11,varint"`
+	VoteScore               int32               `json:"vote_score,omitempty" pb:"16,varint"`
+	Description             string              `json:"description,omitempty" pb:"20,name"`
+	Url                     string              `json:"url,omitempty" pb:"21,name"`
+	FreeAssetNetLimit       int64               `json:"free_asset_net_limit,omitempty" pb:"22,varint"`
+	PublicFreeAssetNetLimit int64               `json:"public_free_asset_net_limit,omitempty" pb:"23,varint"`
+	PublicFreeAssetNetUsage int64               `json:"public_free_asset_net_usage,omitempty" pb:"24,varint"`
+	PublicLatestFreeNetTime int64               `json:"public_latest_free_net_time,omitempty" pb:"25,varint"`
+
+	// Visible is the visible flag the asset was queried with, set by
+	// GetAssetIssueByAccount: the text fields are then plain text.
+	Visible bool `json:"-"`
+}
+
+// AssetFrozenSupply is a part of the supply of an asset frozen by its
+// issuer for FrozenDays.
+type AssetFrozenSupply struct {
+	FrozenAmount int64 `json:"frozen_amount,omitempty" pb:"1,varint"`
+	FrozenDays   int64 `json:"frozen_days,omitempty" pb:"2,varint"`
+}
+
+// NameText returns the name of the asset, hex decoded unless queried with
+// visible addresses.
+func (a *AssetIssue) NameText() string {
+	return bytesText(a.Name, a.Visible)
+}
+
+// AbbrText returns the abbreviation of the asset, hex decoded unless queried with
+// visible addresses.
+func (a *AssetIssue) AbbrText() string {
+	return bytesText(a.Abbr, a.Visible)
+}
+
+// DescriptionText returns the description of the asset, hex decoded unless queried with
+// visible addresses.
+func (a *AssetIssue) DescriptionText() string {
+	return bytesText(a.Description, a.Visible)
+}
+
+// URLText returns the URL of the asset, hex decoded unless queried with
+// visible addresses.
+func (a *AssetIssue) URLText() string {
+	return bytesText(a.Url, a.Visible)
+}
+
+// Amount returns units smallest units of the asset as a TokenAmount with
+// the precision of the asset.
+func (a *AssetIssue) Amount(units int64) TokenAmount {
+	return NewTokenAmount(big.NewInt(units), int(a.Precision))
+}
+
+// StartsAt returns the start of the ICO of the asset.
+func (a *AssetIssue) StartsAt() time.Time {
+	return msToTime(a.StartTime)
+}
+
+// EndsAt returns the end of the ICO of the asset.
+func (a *AssetIssue) EndsAt() time.Time {
+	return msToTime(a.EndTime)
+}
+
+// UpdateAssetContractValue is the parameter of UpdateAssetContract.
+// Description and Url are hex encoded.
+type UpdateAssetContractValue struct {
+	OwnerAddress   string `json:"owner_address,omitempty" pb:"1,address"`
+	Description    string `json:"description,omitempty" pb:"2,name"`
+	Url            string `json:"url,omitempty" pb:"3,name"`
+	NewLimit       int64  `json:"new_limit,omitempty" pb:"4,varint"`
+	NewPublicLimit int64  `json:"new_public_limit,omitempty" pb:"5,varint"`
+}
+
+// UnfreezeAssetContractValue is the parameter of UnfreezeAssetContract.
+type UnfreezeAssetContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+}
+
+// assetList is the response of the endpoints returning several assets.
+type assetList struct {
+	AssetIssue []AssetIssue `json:"assetIssue"`
+}
+
+// hexUnlessVisible hex encodes the text s, unless visible is set, in which
+// case the node expects it as is.
+func hexUnlessVisible(s string, visible bool) string {
+	if visible {
+		return s
+	}
+	return hex.EncodeToString([]byte(s))
+}
+
+// TransferAsset Create a TRC10 transfer of amount units of the asset
+// assetID, e.g. "1000001" as in Account.AssetV2.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) TransferAsset(ownerAddr, toAddr, assetID string, amount int64, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/transferasset", map[string]interface{}{
+		"owner_address": ownerAddr,
+		"to_address":    toAddr,
+		"asset_name":    hexUnlessVisible(assetID, visible),
+		"amount":        amount,
+		"visible":       visible,
+	})
+}
+
+// GetAssetIssueByID Query a TRC10 asset by its id.
+func (c *Client) GetAssetIssueByID(assetID string, consistency Consistency) (*AssetIssue, error) {
+	var asset AssetIssue
+	err := c.post(readPath("getassetissuebyid", consistency), map[string]interface{}{
+		"value": assetID,
+	}, &asset)
+	if err != nil {
+		return nil, err
+	}
+	if asset.ID == "" {
+		return nil, ErrAssetNotFound
+	}
+	return &asset, nil
+}
+
+// GetAssetIssueByAccount Query the TRC10 assets issued by an account.
+func (c *Client) GetAssetIssueByAccount(address string, visible bool) ([]AssetIssue, error) {
+	var list assetList
+	err := c.post("/wallet/getassetissuebyaccount", map[string]interface{}{
+		"address": address,
+		"visible": visible,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.AssetIssue {
+		list.AssetIssue[i].Visible = visible
+	}
+	return list.AssetIssue, nil
+}
+
+// GetAssetIssueList Query all the TRC10 assets.
+func (c *Client) GetAssetIssueList(consistency Consistency) ([]AssetIssue, error) {
+	var list assetList
+	if err := c.post(readPath("getassetissuelist", consistency), nil, &list); err != nil {
+		return nil, err
+	}
+	return list.AssetIssue, nil
+}
+
+// GetPaginatedAssetIssueList Query limit TRC10 assets starting at offset.
+func (c *Client) GetPaginatedAssetIssueList(offset, limit int64, consistency Consistency) ([]AssetIssue, error) {
+	var list assetList
+	err := c.post(readPath("getpaginatedassetissuelist", consistency), map[string]interface{}{
+		"offset": offset,
+		"limit":  limit,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.AssetIssue, nil
+}
+
+// CreateAssetIssue Issue a TRC10 asset. ID, Order, VoteScore and the public
+// usage fields of issue are ignored. Name, Abbr, Description and Url are
+// plain text, hex encoded for the node unless visible. Note: issuing burns
+// a fee (1024 TRX on mainnet).
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) CreateAssetIssue(issue *AssetIssue, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/createassetissue", map[string]interface{}{
+		"owner_address":               issue.OwnerAddress,
+		"name":                        hexUnlessVisible(issue.Name, visible),
+		"abbr":                        hexUnlessVisible(issue.Abbr, visible),
+		"total_supply":                issue.TotalSupply,
+		"frozen_supply":               issue.FrozenSupply,
+		"trx_num":                     issue.TrxNum,
+		"precision":                   issue.Precision,
+		"num":                         issue.Num,
+		"start_time":                  issue.StartTime,
+		"end_time":                    issue.EndTime,
+		"description":                 hexUnlessVisible(issue.Description, visible),
+		"url":                         hexUnlessVisible(issue.Url, visible),
+		"free_asset_net_limit":        issue.FreeAssetNetLimit,
+		"public_free_asset_net_limit": issue.PublicFreeAssetNetLimit,
+		"visible":                     visible,
+	})
+}
+
+// ParticipateAssetIssue Buy the TRC10 asset assetID from its issuer
+// issuerAddr during its ICO, spending amount.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) ParticipateAssetIssue(ownerAddr, issuerAddr, assetID string, amount Sun, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/participateassetissue", map[string]interface{}{
+		"owner_address": ownerAddr,
+		"to_address":    issuerAddr,
+		"asset_name":    hexUnlessVisible(assetID, visible),
+		"amount":        amount,
+		"visible":       visible,
+	})
+}
+
+// UpdateAsset Update the description, URL and free bandwidth limits of the
+// TRC10 asset issued by ownerAddr.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) UpdateAsset(ownerAddr, description, url string, newLimit, newPublicLimit int64, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/updateasset", map[string]interface{}{
+		"owner_address":    ownerAddr,
+		"description":      hexUnlessVisible(description, visible),
+		"url":              hexUnlessVisible(url, visible),
+		"new_limit":        newLimit,
+		"new_public_limit": newPublicLimit,
+		"visible":          visible,
+	})
+}
+
+// UnfreezeAsset Unfreeze the expired frozen supply of the TRC10 asset
+// issued by ownerAddr.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) UnfreezeAsset(ownerAddr string, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/unfreezeasset", map[string]interface{}{
+		"owner_address": ownerAddr,
+		"visible":       visible,
+	})
+}
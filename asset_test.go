@@ -0,0 +1,81 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// The /wallet/getassetissuebyaccount responses for the same asset, queried
+// with visible false and true.
+const (
+	assetListJSON = `{"assetIssue": [{
+	"owner_address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a",
+	"name": "63616665",
+	"abbr": "434146",
+	"total_supply": 1000000000000,
+	"trx_num": 1000000,
+	"precision": 6,
+	"num": 1,
+	"start_time": 1600000000000,
+	"end_time": 1700000000000,
+	"description": "612063616665",
+	"url": "68747470733a2f2f636166652e6578616d706c65",
+	"id": "1002000"
+}]}`
+
+	visibleAssetListJSON = `{"assetIssue": [{
+	"owner_address": "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH",
+	"name": "cafe",
+	"abbr": "CAF",
+	"total_supply": 1000000000000,
+	"trx_num": 1000000,
+	"precision": 6,
+	"num": 1,
+	"start_time": 1600000000000,
+	"end_time": 1700000000000,
+	"description": "a cafe",
+	"url": "https://cafe.example",
+	"id": "1002000"
+}]}`
+)
+
+func TestGetAssetIssueByAccountVisible(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Visible bool `json:"visible"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Visible {
+			fmt.Fprint(w, visibleAssetListJSON)
+		} else {
+			fmt.Fprint(w, assetListJSON)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	for _, visible := range []bool{false, true} {
+		assets, err := c.GetAssetIssueByAccount("TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", visible)
+		if err != nil || len(assets) != 1 {
+			t.Fatalf("GetAssetIssueByAccount = %v, %v", assets, err)
+		}
+		a := assets[0]
+		tests := []struct {
+			name      string
+			got, want string
+		}{
+			{"NameText", a.NameText(), "cafe"},
+			{"AbbrText", a.AbbrText(), "CAF"},
+			{"DescriptionText", a.DescriptionText(), "a cafe"},
+			{"URLText", a.URLText(), "https://cafe.example"},
+		}
+		for _, tt := range tests {
+			if tt.got != tt.want {
+				t.Errorf("visible %v: %s = %q, want %q", visible, tt.name, tt.got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package tronhttpClient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrEntropyLength is returned when the entropy is not a multiple of 32 bits
+	// in the range [128, 256].
+	ErrEntropyLength = errors.New("bip39: entropy length must be [128, 256] and a multiple of 32")
+	// ErrInvalidMnemonic is returned when a mnemonic has an unknown word,
+	// a wrong number of words or a wrong checksum.
+	ErrInvalidMnemonic = errors.New("bip39: invalid mnemonic")
+)
+
+var englishWordIndex = func() map[string]int {
+	m := make(map[string]int, len(englishWordList))
+	for i, w := range englishWordList {
+		m[w] = i
+	}
+	return m
+}()
+
+// NewEntropy returns bitSize random bits to be used with NewMnemonic.
+// bitSize must be a multiple of 32 in the range [128, 256].
+func NewEntropy(bitSize int) ([]byte, error) {
+	if bitSize < 128 || bitSize > 256 || bitSize%32 != 0 {
+		return nil, ErrEntropyLength
+	}
+
+	entropy := make([]byte, bitSize/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// NewMnemonic returns the BIP39 english mnemonic sentence of entropy.
+func NewMnemonic(entropy []byte) (string, error) {
+	bitSize := len(entropy) * 8
+	if bitSize < 128 || bitSize > 256 || bitSize%32 != 0 {
+		return "", ErrEntropyLength
+	}
+
+	// entropy || first (bitSize / 32) bits of SHA-256(entropy), split in
+	// 11 bits groups, each one is the index of a word.
+	checksumBits := uint(bitSize / 32)
+	hash := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	count := (bitSize + int(checksumBits)) / 11
+	words := make([]string, count)
+	mask := big.NewInt(2047)
+	for i := count - 1; i >= 0; i-- {
+		words[i] = englishWordList[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy validates mnemonic and returns the entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := new(big.Int)
+	for _, w := range words {
+		idx, ok := englishWordIndex[w]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(idx)))
+	}
+
+	checksumBits := uint(len(words) * 11 / 33)
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1))
+	data.Rsh(data, checksumBits)
+
+	entropy := make([]byte, len(words)*11*32/33/8)
+	b := data.Bytes()
+	copy(entropy[len(entropy)-len(b):], b)
+
+	hash := sha256.Sum256(entropy)
+	if checksum.Int64() != int64(hash[0]>>(8-checksumBits)) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return entropy, nil
+}
+
+// IsMnemonicValid reports whether mnemonic is a valid BIP39 english mnemonic.
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err == nil
+}
+
+// NewSeed returns the 64 bytes BIP39 seed of mnemonic protected by passphrase.
+// The mnemonic checksum is not verified, use NewSeedWithErrorChecking for that.
+// Neither mnemonic nor passphrase are NFKD normalized, so they must be given
+// already normalized when using non ASCII characters.
+func NewSeed(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+// NewSeedWithErrorChecking validates mnemonic and returns its BIP39 seed.
+func NewSeedWithErrorChecking(mnemonic, passphrase string) ([]byte, error) {
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	return NewSeed(mnemonic, passphrase), nil
+}
@@ -0,0 +1,133 @@
+package tronhttpClient
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrBlockNotFound is returned when the node has no block with the requested
+// number or id.
+var ErrBlockNotFound = errors.New("block not found")
+
+// Block is a block as returned by the block endpoints of the node.
+type Block struct {
+	BlockID      string        `json:"blockID"`
+	BlockHeader  BlockHeader   `json:"block_header"`
+	Transactions []Transaction `json:"transactions,omitempty"`
+}
+
+// BlockHeader is the header of a Block.
+type BlockHeader struct {
+	RawData          BlockHeaderRawData `json:"raw_data"`
+	WitnessSignature string             `json:"witness_signature,omitempty"`
+}
+
+// BlockHeaderRawData is the signed part of a BlockHeader.
+type BlockHeaderRawData struct {
+	Number         int64  `json:"number,omitempty"`
+	TxTrieRoot     string `json:"txTrieRoot,omitempty"`
+	WitnessAddress string `json:"witness_address,omitempty"`
+	ParentHash     string `json:"parentHash,omitempty"`
+	Version        int32  `json:"version,omitempty"`
+	Timestamp      int64  `json:"timestamp,omitempty"`
+}
+
+// Number returns the number of b.
+func (b *Block) Number() int64 {
+	return b.BlockHeader.RawData.Number
+}
+
+// Time returns the time b was produced.
+func (b *Block) Time() time.Time {
+	return msToTime(b.BlockHeader.RawData.Timestamp)
+}
+
+// RefBlock returns b as the reference block of a TxBuilder.
+func (b *Block) RefBlock() RefBlock {
+	return RefBlock{Number: b.Number(), ID: b.BlockID}
+}
+
+// blockList is the response of the endpoints returning several blocks.
+type blockList struct {
+	Block []Block `json:"block"`
+}
+
+// getBlock queries path and returns ErrBlockNotFound when the node answers
+// with an empty block.
+func (c *Client) getBlock(ctx context.Context, path string, params interface{}) (*Block, error) {
+	var block Block
+	if err := c.postContext(ctx, path, params, &block); err != nil {
+		return nil, err
+	}
+	if block.BlockID == "" {
+		return nil, ErrBlockNotFound
+	}
+	return &block, nil
+}
+
+// GetNowBlock Query the latest block, or the latest solidified block.
+func (c *Client) GetNowBlock(visible bool, consistency Consistency) (*Block, error) {
+	return c.getNowBlock(context.Background(), visible, consistency)
+}
+
+func (c *Client) getNowBlock(ctx context.Context, visible bool, consistency Consistency) (*Block, error) {
+	return c.getBlock(ctx, readPath("getnowblock", consistency), map[string]interface{}{
+		"visible": visible,
+	})
+}
+
+// GetBlockByNum Query a block by its number.
+func (c *Client) GetBlockByNum(num int64, visible bool, consistency Consistency) (*Block, error) {
+	return c.getBlock(context.Background(), readPath("getblockbynum", consistency), map[string]interface{}{
+		"num":     num,
+		"visible": visible,
+	})
+}
+
+// GetBlockByID Query a block by its id.
+func (c *Client) GetBlockByID(id string, visible bool, consistency Consistency) (*Block, error) {
+	return c.getBlock(context.Background(), readPath("getblockbyid", consistency), map[string]interface{}{
+		"value":   id,
+		"visible": visible,
+	})
+}
+
+// GetBlock Query a block by its number or id. Without detail the
+// transactions are not returned, only the header.
+func (c *Client) GetBlock(idOrNum string, detail bool, visible bool, consistency Consistency) (*Block, error) {
+	return c.getBlock(context.Background(), readPath("getblock", consistency), map[string]interface{}{
+		"id_or_num": idOrNum,
+		"detail":    detail,
+		"visible":   visible,
+	})
+}
+
+// GetBlockByLimitNext Query the blocks with numbers in [start, end). The node
+// returns at most 100 blocks.
+func (c *Client) GetBlockByLimitNext(start, end int64, visible bool, consistency Consistency) ([]Block, error) {
+	var list blockList
+	err := c.post(readPath("getblockbylimitnext", consistency), map[string]interface{}{
+		"startNum": start,
+		"endNum":   end,
+		"visible":  visible,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Block, nil
+}
+
+// GetBlockByLatestNum Query the latest num blocks. The node returns at most
+// 100 blocks.
+func (c *Client) GetBlockByLatestNum(num int64, visible bool, consistency Consistency) ([]Block, error) {
+	var list blockList
+	err := c.post(readPath("getblockbylatestnum", consistency), map[string]interface{}{
+		"num":     num,
+		"visible": visible,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Block, nil
+}
@@ -0,0 +1,85 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// blockJSON is a GetBlockByNum response, with a transaction of a contract
+// type unknown to the package.
+const blockJSON = `{
+	"blockID": "0000000002faf0807c2b3a44a0b5b3e4cf1cf1d5c0f6fdb2d6e0b0c26cb5e4ae",
+	"block_header": {
+		"raw_data": {"number": 50000000, "txTrieRoot": "aa", "witness_address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a", "parentHash": "bb", "version": 27, "timestamp": 1681000000000},
+		"witness_signature": "cc"
+	},
+	"transactions": [
+		{
+			"ret": [{"contractRet": "SUCCESS"}],
+			"signature": ["dd"],
+			"txID": "ee",
+			"raw_data": {
+				"contract": [{"parameter": {"value": {"data": "a9059cbb", "owner_address": "41c8599111f29c1e1e061265b4af93ea1f274ad78a", "contract_address": "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"}, "type_url": "type.googleapis.com/protocol.TriggerSmartContract"}, "type": "TriggerSmartContract"}],
+				"ref_block_bytes": "f06e", "ref_block_hash": "1111111111111111", "expiration": 1681000057000, "fee_limit": 100000000, "timestamp": 1681000000000
+			},
+			"raw_data_hex": "0a02f06e"
+		},
+		{
+			"txID": "ff",
+			"raw_data": {"contract": [{"parameter": {"value": {"x": 1}, "type_url": "type.googleapis.com/protocol.FutureContract"}, "type": "FutureContract"}]}
+		}
+	]
+}`
+
+func TestBlockUnmarshal(t *testing.T) {
+	var b Block
+	if err := json.Unmarshal([]byte(blockJSON), &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Number() != 50000000 {
+		t.Errorf("Number = %d, want 50000000", b.Number())
+	}
+	if got := b.Time().UnixNano() / 1e6; got != 1681000000000 {
+		t.Errorf("Time = %d ms, want 1681000000000", got)
+	}
+	if ref := b.RefBlock(); ref.Number != 50000000 || ref.ID != b.BlockID {
+		t.Errorf("RefBlock = %+v", ref)
+	}
+	if len(b.Transactions) != 2 {
+		t.Fatalf("%d transactions, want 2", len(b.Transactions))
+	}
+
+	tests := []struct {
+		tx      int
+		typ     string
+		known   bool
+		valueOK func(interface{}) bool
+	}{
+		{0, "TriggerSmartContract", true, func(v interface{}) bool {
+			c, ok := v.(*TriggerSmartContractValue)
+			return ok && c.Data == "a9059cbb" && c.ContractAddress == "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+		}},
+		{1, "FutureContract", false, func(v interface{}) bool {
+			raw, ok := v.(json.RawMessage)
+			return ok && string(raw) == `{"x": 1}`
+		}},
+	}
+	for _, tt := range tests {
+		c := b.Transactions[tt.tx].RawData.Contract[0]
+		if c.Type.Known() != tt.known {
+			t.Errorf("transaction %d: Known = %v, want %v", tt.tx, c.Type.Known(), tt.known)
+		}
+		var typ struct {
+			Type json.RawMessage `json:"type"`
+		}
+		out, _ := json.Marshal(c)
+		json.Unmarshal(out, &typ)
+		if string(typ.Type) != `"`+tt.typ+`"` {
+			t.Errorf("transaction %d: type = %s, want %s", tt.tx, typ.Type, tt.typ)
+		}
+		if !tt.valueOK(c.Parameter.Value) {
+			t.Errorf("transaction %d: unexpected value %#v", tt.tx, c.Parameter.Value)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Broadcast result codes of the node.
+const (
+	BroadcastSuccess               = "SUCCESS"
+	BroadcastSigError              = "SIGERROR"
+	BroadcastContractValidateError = "CONTRACT_VALIDATE_ERROR"
+	BroadcastBandwidthError        = "BANDWITH_ERROR" // sic, as spelled by the node
+	BroadcastDuplicateError        = "DUP_TRANSACTION_ERROR"
+	BroadcastTaposError            = "TAPOS_ERROR"
+	BroadcastExpirationError       = "TRANSACTION_EXPIRATION_ERROR"
+	BroadcastServerBusy            = "SERVER_BUSY"
+)
+
+// BroadcastError is returned when the node refuses a transaction.
+type BroadcastError struct {
+	TxID    string
+	Code    string
+	Message string
+}
+
+func (e *BroadcastError) Error() string {
+	return fmt.Sprintf("broadcast of transaction %s refused: %s: %s", e.TxID, e.Code, e.Message)
+}
+
+// broadcastResult is the response of /wallet/broadcasttransaction.
+type broadcastResult struct {
+	Result  bool   `json:"result"`
+	Code    string `json:"code"`
+	TxID    string `json:"txid"`
+	Message string `json:"message"`
+}
+
+// decodeHexText returns the text hex encoded in s, or s when it is not hex.
+func decodeHexText(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
+
+// bytesText returns the text of s, a protobuf bytes field in a response of
+// the node: plain text when the request was visible, hex otherwise.
+func bytesText(s string, visible bool) string {
+	if visible {
+		return s
+	}
+	return decodeHexText(s)
+}
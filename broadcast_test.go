@@ -0,0 +1,67 @@
+package tronhttpClient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// signedTransferHex returns a signed transfer expiring in 2100, encoded as
+// expected by BroadcastHex.
+func signedTransferHex(t *testing.T) (txHex, txID string) {
+	t.Helper()
+	tx := &Transaction{RawData: RawData{
+		RefBlockBytes: "e0a3",
+		RefBlockHash:  "0102030405060708",
+		Expiration:    4102444800000,
+		Timestamp:     4102444740000,
+		Contract: []Contract{{
+			Type: TransferContract,
+			Parameter: ContractParameter{TypeURL: TransferContract.TypeURL(), Value: &TransferContractValue{
+				OwnerAddress: ownerHex,
+				ToAddress:    receiverHex,
+				Amount:       1000000,
+			}},
+		}},
+	}}
+	if err := tx.UpdateRawDataHex(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SignTx(tx, "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28"); err != nil {
+		t.Fatal(err)
+	}
+	txHex, err := TransactionToHex(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return txHex, tx.TxId
+}
+
+func TestBroadcastHex(t *testing.T) {
+	txHex, txID := signedTransferHex(t)
+
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	response = `{"result":true,"code":"SUCCESS","message":"","txid":"` + txID + `"}`
+	tx, err := c.BroadcastHex(txHex)
+	if err != nil {
+		t.Fatalf("BroadcastHex: %v", err)
+	}
+	if tx.TxId != txID || len(tx.Signature) != 1 {
+		t.Errorf("BroadcastHex = %s with %d signatures, want %s with 1", tx.TxId, len(tx.Signature), txID)
+	}
+
+	response = `{"result":false,"code":"SIGERROR","message":"Validate signature error","txid":"` + txID + `"}`
+	_, err = c.BroadcastHex(txHex)
+	var berr *BroadcastError
+	if !errors.As(err, &berr) || berr.Code != BroadcastSigError || berr.TxID != txID || berr.Message != "Validate signature error" {
+		t.Errorf("BroadcastHex of a refused transaction = %v, want a *BroadcastError", err)
+	}
+}
@@ -0,0 +1,136 @@
+package tronhttpClient
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// DefaultExpiration is the expiration the node gives to the transactions it
+// creates, relative to their creation.
+const DefaultExpiration = time.Minute
+
+// MaxExpiration is the longest expiration accepted by the node, relative to
+// the head block.
+const MaxExpiration = 24 * time.Hour
+
+var (
+	// ErrInvalidRefBlock is returned when a reference block id is not a 32
+	// bytes hex string starting with the block number.
+	ErrInvalidRefBlock = errors.New("invalid reference block id")
+	// ErrInvalidExpiration is returned when an expiration is not positive or
+	// exceeds MaxExpiration.
+	ErrInvalidExpiration = errors.New("expiration must be positive and at most 24h")
+)
+
+// RefBlock is the block referenced by a transaction (TAPOS): the node only
+// accepts transactions referencing one of its last 65536 blocks, which
+// prevents replays on other chains and forks.
+type RefBlock struct {
+	Number int64
+	// ID is the hex encoded 32 bytes block id, whose first 8 bytes are the
+	// block number.
+	ID string
+}
+
+// RefBlockFromID returns the RefBlock of a hex encoded block id.
+func RefBlockFromID(blockID string) (*RefBlock, error) {
+	b, err := hex.DecodeString(blockID)
+	if err != nil || len(b) != 32 {
+		return nil, ErrInvalidRefBlock
+	}
+	return &RefBlock{Number: int64(binary.BigEndian.Uint64(b[:8])), ID: blockID}, nil
+}
+
+// refBlockBytes returns the ref_block_bytes and ref_block_hash of r: bytes
+// 6 and 7 of the block number and bytes 8 to 15 of the block id.
+func (r *RefBlock) refBlockBytes() (string, string, error) {
+	id, err := hex.DecodeString(r.ID)
+	if err != nil || len(id) != 32 {
+		return "", "", ErrInvalidRefBlock
+	}
+
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], uint64(r.Number))
+	return hex.EncodeToString(num[6:8]), hex.EncodeToString(id[8:16]), nil
+}
+
+// TxBuilder builds transactions offline, without asking the node to create
+// them: only a recent block has to be fetched once, then any number of
+// transactions can be built, signed with SignTx and broadcast with
+// BroadcastTx.
+type TxBuilder struct {
+	refBlock   RefBlock
+	expiration time.Duration
+	visible    bool
+	now        func() time.Time
+}
+
+// NewTxBuilder returns a TxBuilder referencing refBlock, which should be a
+// recent (ideally solidified) block.
+func NewTxBuilder(refBlock RefBlock) *TxBuilder {
+	return &TxBuilder{refBlock: refBlock, expiration: DefaultExpiration, now: time.Now}
+}
+
+// SetExpiration sets how long after being built the transactions expire,
+// up to MaxExpiration.
+func (b *TxBuilder) SetExpiration(d time.Duration) error {
+	if d <= 0 || d > MaxExpiration {
+		return ErrInvalidExpiration
+	}
+	b.expiration = d
+	return nil
+}
+
+// SetVisible sets whether the built transactions have their addresses in
+// base58 (true) or hex (false, the default).
+func (b *TxBuilder) SetVisible(visible bool) {
+	b.visible = visible
+}
+
+// Build fills the reference block, timestamp and expiration of raw, encodes
+// it and returns the unsigned Transaction with its TxId and RawDataHex.
+// Contracts without type_url get the one of their type. The contract
+// values of raw are normalized to the address format of the builder.
+func (b *TxBuilder) Build(raw RawData) (*Transaction, error) {
+	refBytes, refHash, err := b.refBlock.refBlockBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := b.now()
+	raw.RefBlockBytes = refBytes
+	raw.RefBlockHash = refHash
+	raw.Timestamp = now.UnixNano() / int64(time.Millisecond)
+	raw.Expiration = now.Add(b.expiration).UnixNano() / int64(time.Millisecond)
+
+	encoded, err := EncodeRawData(&raw, b.visible)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoding back normalizes addresses and fills the type_url.
+	tx, err := DecodeTransaction(appendBytesField(nil, 1, encoded), b.visible)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewContract returns a contract of type t with parameter value, one of the
+// *Value structs.
+func NewContract(t ContractType, value interface{}) Contract {
+	return Contract{Type: t, Parameter: ContractParameter{TypeURL: t.TypeURL(), Value: value}}
+}
+
+// Transfer builds a TRX transfer of amount from owner to to.
+func (b *TxBuilder) Transfer(owner, to string, amount Sun) (*Transaction, error) {
+	return b.Build(RawData{
+		Contract: []Contract{NewContract(TransferContract, &TransferContractValue{
+			OwnerAddress: owner,
+			ToAddress:    to,
+			Amount:       amount,
+		})},
+	})
+}
@@ -60,6 +60,9 @@ const (
 	querySep = "?"
 )
 
+// CallRetryable sends req, retrying on network errors and retryable status
+// codes. It stops retrying and returns the context error once the context
+// of req is done.
 func (c *Client) CallRetryable(req *http.Request) (reply io.ReadCloser, err error) {
 	var reqRetry = MaxRetry // Indicates how many times we can retry the request
 
@@ -69,13 +72,28 @@ func (c *Client) CallRetryable(req *http.Request) (reply io.ReadCloser, err erro
 	// Indicate to our routine to exit cleanly upon return.
 	defer close(doneCh)
 
-	for range newRetryTimer(reqRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter, doneCh) {
+	ctx := req.Context()
+	attemptCh := newRetryTimer(reqRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter, doneCh)
+	for {
+		// Wait for the next attempt, unless the context is done first.
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case _, ok := <-attemptCh:
+			if !ok {
+				return nil, &NetworkError{errors.New("failed to fetch the resource: " + req.URL.String())}
+			}
+		}
+
 		// Instantiate a new request.
 		var resp *http.Response
 
 		// Initiate the request.
 		resp, err = c.httpClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			// For supported rest requests errors verify.
 			if isHTTPReqErrorRetryable(err) {
 				continue // Retry.
@@ -96,9 +114,8 @@ func (c *Client) CallRetryable(req *http.Request) (reply io.ReadCloser, err erro
 			continue // Retry.
 		}
 
-		break
+		return nil, &NetworkError{errors.New("failed to fetch the resource: " + req.URL.String())}
 	}
-	return nil, &NetworkError{errors.New("failed to fetch the resource: " + req.URL.String())}
 }
 
 // Close closes all idle connections of the underlying http client
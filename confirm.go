@@ -0,0 +1,200 @@
+package tronhttpClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default polling intervals of WaitForConfirmation. Blocks are produced
+// every 3 seconds.
+const (
+	DefaultPollInterval    = time.Second
+	DefaultMaxPollInterval = 10 * time.Second
+)
+
+// ConfirmOptions are the options of WaitForConfirmation. The zero value waits
+// until the transaction is included in a block.
+type ConfirmOptions struct {
+	// Confirmations is the number of blocks that must be produced on top of
+	// the block including the transaction.
+	Confirmations int64
+	// Solidified waits until the solidity node has executed the transaction,
+	// once the block including it is irreversible (about 1 minute).
+	Solidified bool
+	// Expiration is the expiration of the transaction. When set, a
+	// *TxExpiredError is returned as soon as the head block is past it and
+	// the transaction was not included.
+	Expiration time.Time
+	// PollInterval is the first interval between polls, doubled after each
+	// poll up to MaxPollInterval.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+}
+
+// TxFailedError is returned by WaitForConfirmation when the transaction was
+// included but its execution failed (reverted, out of energy, ...). The fee
+// is still paid.
+type TxFailedError struct {
+	TxID string
+	Info *TransactionInfo
+}
+
+func (e *TxFailedError) Error() string {
+	msg := fmt.Sprintf("transaction %s failed", e.TxID)
+	if e.Info.Receipt.Result != "" {
+		msg += ": " + e.Info.Receipt.Result
+	}
+	if m := e.Info.ResultMessage(); m != "" {
+		msg += ": " + m
+	}
+	return msg
+}
+
+// TxNotFoundError is returned by WaitForConfirmation when the transaction
+// was still not found when the context was done. It matches ErrTxNotFound
+// with errors.Is and unwraps to the context error.
+type TxNotFoundError struct {
+	TxID string
+	Err  error
+}
+
+func (e *TxNotFoundError) Error() string {
+	return fmt.Sprintf("transaction %s not found: %v", e.TxID, e.Err)
+}
+
+func (e *TxNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTxNotFound.
+func (e *TxNotFoundError) Is(target error) bool {
+	return target == ErrTxNotFound
+}
+
+// WaitForConfirmation polls the node until the transaction txID is included
+// in a block, has opts.Confirmations blocks on top of it, or is solidified,
+// and returns its receipt. Polls back off from opts.PollInterval to
+// opts.MaxPollInterval.
+//
+// A *TxFailedError is returned when the execution failed, a *TxExpiredError
+// when opts.Expiration is set and passed, and a *TxNotFoundError when ctx
+// is done before the transaction is found. ctx also cancels the pending
+// poll. Other errors, such as network errors or unexpected responses of the
+// node, do not end the wait: polling goes on until ctx is done, and the
+// error then returned wraps ctx.Err() and mentions the last of them.
+func (c *Client) WaitForConfirmation(ctx context.Context, txID string, opts *ConfirmOptions) (*TransactionInfo, error) {
+	if opts == nil {
+		opts = &ConfirmOptions{}
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	maxInterval := opts.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+
+	found := false
+	var last error
+	for {
+		info, done, err := c.pollConfirmation(ctx, txID, opts)
+		switch {
+		case terminal(err):
+			return nil, err
+		case err != nil:
+			// An error caused by ctx itself is reported below.
+			if ctx.Err() == nil {
+				last = err
+			}
+		case done:
+			return info, nil
+		default:
+			last = nil
+			found = found || info != nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if !found {
+				return nil, &TxNotFoundError{TxID: txID, Err: waitError(ctx, last)}
+			}
+			return nil, fmt.Errorf("transaction %s: waiting for confirmation: %w", txID, waitError(ctx, last))
+		case <-timer.C:
+		}
+
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// terminal reports whether err ends WaitForConfirmation: the transaction
+// failed or expired. Other errors may be transient.
+func terminal(err error) bool {
+	var failed *TxFailedError
+	var expired *TxExpiredError
+	return errors.As(err, &failed) || errors.As(err, &expired)
+}
+
+// waitError returns the error of a wait ended by ctx: ctx.Err(), wrapped
+// with last, the error of the last poll, if it failed.
+func waitError(ctx context.Context, last error) error {
+	if last == nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("%w (last poll: %v)", ctx.Err(), last)
+}
+
+// pollConfirmation polls the transaction once. It returns the receipt of the
+// full node when the transaction is included, and whether opts are
+// satisfied.
+func (c *Client) pollConfirmation(ctx context.Context, txID string, opts *ConfirmOptions) (*TransactionInfo, bool, error) {
+	info, err := c.getTransactionInfoByID(ctx, txID, Latest)
+	if errors.Is(err, ErrTxNotFound) {
+		if opts.Expiration.IsZero() {
+			return nil, false, nil
+		}
+		block, err := c.getNowBlock(ctx, false, Latest)
+		if err != nil {
+			return nil, false, err
+		}
+		if block.Time().After(opts.Expiration) {
+			return nil, false, &TxExpiredError{TxID: txID, Expiration: opts.Expiration}
+		}
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Failed() {
+		return nil, false, &TxFailedError{TxID: txID, Info: info}
+	}
+
+	if opts.Confirmations > 0 {
+		block, err := c.getNowBlock(ctx, false, Latest)
+		if err != nil {
+			return nil, false, err
+		}
+		if block.Number()-info.BlockNumber < opts.Confirmations {
+			return info, false, nil
+		}
+	}
+
+	if opts.Solidified {
+		solid, err := c.getTransactionInfoByID(ctx, txID, Solidified)
+		if errors.Is(err, ErrTxNotFound) {
+			return info, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return solid, true, nil
+	}
+
+	return info, true, nil
+}
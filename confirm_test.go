@@ -0,0 +1,73 @@
+package tronhttpClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const confirmTxID = "c5aa1ab6fcb5e1a1cb1cb5d6b3e2d4c4a7b0f0a4c3e4f5a6b7c8d9e0f1a2b3c4"
+
+// An unexpected response of the node does not end the wait.
+func TestWaitForConfirmationTransientError(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) == 1 {
+			fmt.Fprint(w, "<html>502 Bad Gateway</html>")
+			return
+		}
+		fmt.Fprintf(w, `{"id":"%s","blockNumber":100,"blockTimeStamp":1681000003000,"receipt":{"net_usage":268}}`, confirmTxID)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	info, err := c.WaitForConfirmation(ctx, confirmTxID, &ConfirmOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForConfirmation: %v", err)
+	}
+	if info.BlockNumber != 100 || atomic.LoadInt32(&polls) != 2 {
+		t.Errorf("WaitForConfirmation = block %d after %d polls, want block 100 after 2", info.BlockNumber, polls)
+	}
+}
+
+// When ctx is done, the error wraps ctx.Err() and reports the last error.
+func TestWaitForConfirmationTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html>502 Bad Gateway</html>")
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.WaitForConfirmation(ctx, confirmTxID, &ConfirmOptions{PollInterval: time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("WaitForConfirmation = %v, want a TxNotFoundError wrapping context.DeadlineExceeded", err)
+	}
+	if !strings.Contains(err.Error(), "invalid character") {
+		t.Errorf("WaitForConfirmation = %v, want the last poll error", err)
+	}
+}
+
+// A failed transaction ends the wait at once.
+func TestWaitForConfirmationFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":"%s","blockNumber":100,"result":"FAILED","receipt":{"result":"REVERT"}}`, confirmTxID)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	_, err := c.WaitForConfirmation(context.Background(), confirmTxID, nil)
+	var failed *TxFailedError
+	if !errors.As(err, &failed) {
+		t.Errorf("WaitForConfirmation = %v, want a *TxFailedError", err)
+	}
+}
@@ -0,0 +1,141 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/stdevHsequeda/TRONHttpClient/abi"
+)
+
+// ErrNoMethod is returned when a ContractCall has neither a Function nor a
+// Method.
+var ErrNoMethod = errors.New("contract call has no function")
+
+// ContractCall is a call of a smart contract function.
+type ContractCall struct {
+	// OwnerAddress is the caller. Constant calls default to the contract
+	// itself.
+	OwnerAddress    string
+	ContractAddress string
+	// Function is the signature of the called function, e.g.
+	// "transfer(address,uint256)", optionally followed by its outputs, e.g.
+	// "balanceOf(address) returns (uint256)", to decode the constant result.
+	Function string
+	// Method is the called function, e.g. from abi.Parse. It takes
+	// precedence over Function.
+	Method *abi.Method
+	// Args are the arguments of the function, see abi.Encode.
+	Args []interface{}
+	// CallValue is the TRX sent to the contract.
+	CallValue Sun
+	// CallTokenValue is the amount of the TRC10 asset TokenID sent to the
+	// contract.
+	CallTokenValue int64
+	TokenID        int64
+	// FeeLimit is the most TRX the call may burn for energy, DefaultFeeLimit
+	// when zero. Constant calls ignore it.
+	FeeLimit Sun
+	// PermissionID is the permission of the owner signing the transaction.
+	PermissionID int
+	Visible      bool
+}
+
+// ConstantCallResult is the result of a constant call.
+type ConstantCallResult struct {
+	Result TriggerResult
+	// Outputs are the decoded outputs of the function, nil when its outputs
+	// are unknown or the call reverted.
+	Outputs []interface{}
+	// Output is the raw output of the function, or the revert data when the
+	// call reverted.
+	Output []byte
+	// Revert is set when the call reverted, with the decoded reason.
+	Revert     *RevertError
+	EnergyUsed int64
+}
+
+// method returns the called function of call.
+func (call *ContractCall) method() (*abi.Method, error) {
+	if call.Method != nil {
+		return call.Method, nil
+	}
+	if call.Function == "" {
+		return nil, ErrNoMethod
+	}
+	return abi.ParseMethod(call.Function)
+}
+
+// trigger returns the trigger of call, with the arguments encoded.
+func (call *ContractCall) trigger() (*triggerCall, *abi.Method, error) {
+	m, err := call.method()
+	if err != nil {
+		return nil, nil, err
+	}
+	param, err := m.Inputs.Pack(call.Args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &triggerCall{
+		OwnerAddress:    call.OwnerAddress,
+		ContractAddress: call.ContractAddress,
+		Selector:        m.Sig,
+		Parameter:       hex.EncodeToString(param),
+		CallValue:       call.CallValue,
+		CallTokenValue:  call.CallTokenValue,
+		TokenID:         call.TokenID,
+		FeeLimit:        call.FeeLimit,
+		PermissionID:    call.PermissionID,
+		Visible:         call.Visible,
+	}, m, nil
+}
+
+// TriggerConstantContract Execute a call of a smart contract function
+// without creating a transaction, e.g. to read a view function or estimate
+// the energy of a call. When the call reverts, the result is returned with
+// Revert set, along with the same *RevertError as the error.
+func (c *Client) TriggerConstantContract(call *ContractCall, consistency Consistency) (*ConstantCallResult, error) {
+	tc, m, err := call.trigger()
+	if err != nil {
+		return nil, err
+	}
+	if tc.OwnerAddress == "" {
+		tc.OwnerAddress = tc.ContractAddress
+	}
+
+	resp, callErr := c.triggerConstant(tc, consistency)
+	if resp == nil {
+		return nil, callErr
+	}
+	result := &ConstantCallResult{Result: resp.Result, EnergyUsed: resp.EnergyUsed}
+	if len(resp.ConstantResult) > 0 {
+		if result.Output, err = hex.DecodeString(resp.ConstantResult[0]); err != nil {
+			return nil, err
+		}
+	}
+	if callErr != nil {
+		result.Revert, _ = callErr.(*RevertError)
+		return result, callErr
+	}
+
+	if len(m.Outputs) > 0 {
+		if result.Outputs, err = m.Outputs.Unpack(result.Output); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// TriggerSmartContract Create the transaction of a call of a smart contract
+// function, along with the result returned by the node.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) TriggerSmartContract(call *ContractCall) (*Transaction, *TriggerResult, error) {
+	tc, _, err := call.trigger()
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.triggerSmart(tc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Transaction, &resp.Result, nil
+}
@@ -0,0 +1,224 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ContractType is the type of a transaction contract, the
+// Transaction.Contract.ContractType protobuf enum.
+type ContractType int32
+
+// Contract types, with the values of the protobuf enum.
+const (
+	AccountCreateContract           ContractType = 0
+	TransferContract                ContractType = 1
+	TransferAssetContract           ContractType = 2
+	VoteAssetContract               ContractType = 3
+	VoteWitnessContract             ContractType = 4
+	WitnessCreateContract           ContractType = 5
+	AssetIssueContract              ContractType = 6
+	WitnessUpdateContract           ContractType = 8
+	ParticipateAssetIssueContract   ContractType = 9
+	AccountUpdateContract           ContractType = 10
+	FreezeBalanceContract           ContractType = 11
+	UnfreezeBalanceContract         ContractType = 12
+	WithdrawBalanceContract         ContractType = 13
+	UnfreezeAssetContract           ContractType = 14
+	UpdateAssetContract             ContractType = 15
+	ProposalCreateContract          ContractType = 16
+	ProposalApproveContract         ContractType = 17
+	ProposalDeleteContract          ContractType = 18
+	SetAccountIdContract            ContractType = 19
+	CustomContract                  ContractType = 20
+	CreateSmartContract             ContractType = 30
+	TriggerSmartContract            ContractType = 31
+	GetContract                     ContractType = 32
+	UpdateSettingContract           ContractType = 33
+	ExchangeCreateContract          ContractType = 41
+	ExchangeInjectContract          ContractType = 42
+	ExchangeWithdrawContract        ContractType = 43
+	ExchangeTransactionContract     ContractType = 44
+	UpdateEnergyLimitContract       ContractType = 45
+	AccountPermissionUpdateContract ContractType = 46
+	ClearABIContract                ContractType = 48
+	UpdateBrokerageContract         ContractType = 49
+	ShieldedTransferContract        ContractType = 51
+	MarketSellAssetContract         ContractType = 52
+	MarketCancelOrderContract       ContractType = 53
+	FreezeBalanceV2Contract         ContractType = 54
+	UnfreezeBalanceV2Contract       ContractType = 55
+	WithdrawExpireUnfreezeContract  ContractType = 56
+	DelegateResourceContract        ContractType = 57
+	UnDelegateResourceContract      ContractType = 58
+	CancelAllUnfreezeV2Contract     ContractType = 59
+)
+
+// UnknownContract is the type of the contracts decoded from JSON with a
+// type name unknown to this package. The protobuf enum has no negative
+// values. The name is kept in Contract.TypeName.
+const UnknownContract ContractType = -1
+
+var contractTypeNames = map[ContractType]string{
+	AccountCreateContract:           "AccountCreateContract",
+	TransferContract:                "TransferContract",
+	TransferAssetContract:           "TransferAssetContract",
+	VoteAssetContract:               "VoteAssetContract",
+	VoteWitnessContract:             "VoteWitnessContract",
+	WitnessCreateContract:           "WitnessCreateContract",
+	AssetIssueContract:              "AssetIssueContract",
+	WitnessUpdateContract:           "WitnessUpdateContract",
+	ParticipateAssetIssueContract:   "ParticipateAssetIssueContract",
+	AccountUpdateContract:           "AccountUpdateContract",
+	FreezeBalanceContract:           "FreezeBalanceContract",
+	UnfreezeBalanceContract:         "UnfreezeBalanceContract",
+	WithdrawBalanceContract:         "WithdrawBalanceContract",
+	UnfreezeAssetContract:           "UnfreezeAssetContract",
+	UpdateAssetContract:             "UpdateAssetContract",
+	ProposalCreateContract:          "ProposalCreateContract",
+	ProposalApproveContract:         "ProposalApproveContract",
+	ProposalDeleteContract:          "ProposalDeleteContract",
+	SetAccountIdContract:            "SetAccountIdContract",
+	CustomContract:                  "CustomContract",
+	CreateSmartContract:             "CreateSmartContract",
+	TriggerSmartContract:            "TriggerSmartContract",
+	GetContract:                     "GetContract",
+	UpdateSettingContract:           "UpdateSettingContract",
+	ExchangeCreateContract:          "ExchangeCreateContract",
+	ExchangeInjectContract:          "ExchangeInjectContract",
+	ExchangeWithdrawContract:        "ExchangeWithdrawContract",
+	ExchangeTransactionContract:     "ExchangeTransactionContract",
+	UpdateEnergyLimitContract:       "UpdateEnergyLimitContract",
+	AccountPermissionUpdateContract: "AccountPermissionUpdateContract",
+	ClearABIContract:                "ClearABIContract",
+	UpdateBrokerageContract:         "UpdateBrokerageContract",
+	ShieldedTransferContract:        "ShieldedTransferContract",
+	MarketSellAssetContract:         "MarketSellAssetContract",
+	MarketCancelOrderContract:       "MarketCancelOrderContract",
+	FreezeBalanceV2Contract:         "FreezeBalanceV2Contract",
+	UnfreezeBalanceV2Contract:       "UnfreezeBalanceV2Contract",
+	WithdrawExpireUnfreezeContract:  "WithdrawExpireUnfreezeContract",
+	DelegateResourceContract:        "DelegateResourceContract",
+	UnDelegateResourceContract:      "UnDelegateResourceContract",
+	CancelAllUnfreezeV2Contract:     "CancelAllUnfreezeV2Contract",
+}
+
+var contractTypeByName = func() map[string]ContractType {
+	m := make(map[string]ContractType, len(contractTypeNames))
+	for t, name := range contractTypeNames {
+		m[name] = t
+	}
+	return m
+}()
+
+// typeURLPrefix prefixes the protobuf message name of contract parameters.
+const typeURLPrefix = "type.googleapis.com/protocol."
+
+// String returns the protobuf name of t, e.g. "TransferContract".
+func (t ContractType) String() string {
+	if name, ok := contractTypeNames[t]; ok {
+		return name
+	}
+	if t == UnknownContract {
+		return "UnknownContract"
+	}
+	return fmt.Sprintf("ContractType(%d)", int32(t))
+}
+
+// Known reports whether t is one of the contract types of this package, as
+// opposed to UnknownContract or a number unknown to it decoded from
+// protobuf.
+func (t ContractType) Known() bool {
+	_, ok := contractTypeNames[t]
+	return ok
+}
+
+// TypeURL returns the type_url of the parameter of contracts of type t.
+func (t ContractType) TypeURL() string {
+	return typeURLPrefix + t.String()
+}
+
+// ContractTypeFromTypeURL returns the ContractType of a parameter type_url.
+func ContractTypeFromTypeURL(typeURL string) (ContractType, bool) {
+	t, ok := contractTypeByName[strings.TrimPrefix(typeURL, typeURLPrefix)]
+	return t, ok
+}
+
+// MarshalJSON encodes t with its name, as the node does, or its number when
+// it has no known name.
+func (t ContractType) MarshalJSON() ([]byte, error) {
+	if name, ok := contractTypeNames[t]; ok {
+		return json.Marshal(name)
+	}
+	return json.Marshal(int32(t))
+}
+
+// UnmarshalJSON decodes t from either its name or its number. Unknown names
+// decode as UnknownContract, so that a transaction with a contract of a
+// newer type still decodes, with its parameter as json.RawMessage.
+func (t *ContractType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		var n int32
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("invalid contract type %s", b)
+		}
+		*t = ContractType(n)
+		return nil
+	}
+
+	v, ok := contractTypeByName[name]
+	if !ok {
+		v = UnknownContract
+	}
+	*t = v
+	return nil
+}
+
+// typeURL returns the type_url of the parameter of c, named after TypeName
+// when its type is unknown.
+func (c *Contract) typeURL() string {
+	if c.Type == UnknownContract {
+		return typeURLPrefix + c.TypeName
+	}
+	return c.Type.TypeURL()
+}
+
+// MarshalJSON encodes c, with TypeName as the type of an UnknownContract.
+func (c Contract) MarshalJSON() ([]byte, error) {
+	type contract Contract
+	v := struct {
+		Type interface{} `json:"type"`
+		contract
+	}{c.Type, contract(c)}
+	if c.Type == UnknownContract && c.TypeName != "" {
+		v.Type = c.TypeName
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes c, keeping in TypeName the name of an unknown type.
+func (c *Contract) UnmarshalJSON(b []byte) error {
+	type contract Contract
+	v := struct {
+		Type json.RawMessage `json:"type"`
+		*contract
+	}{contract: (*contract)(c)}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	c.TypeName = ""
+	if len(v.Type) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(v.Type, &c.Type); err != nil {
+		return err
+	}
+	var name string
+	if c.Type == UnknownContract && json.Unmarshal(v.Type, &name) == nil {
+		c.TypeName = name
+	}
+	return nil
+}
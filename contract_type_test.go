@@ -0,0 +1,41 @@
+package tronhttpClient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestContractJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		typ      ContractType
+		typeName string
+		typeURL  string
+	}{
+		{`{"type":"TransferContract","parameter":{"type_url":"type.googleapis.com/protocol.TransferContract","value":{}}}`, TransferContract, "", "type.googleapis.com/protocol.TransferContract"},
+		{`{"type":1,"parameter":{"type_url":"type.googleapis.com/protocol.TransferContract","value":{}}}`, TransferContract, "", "type.googleapis.com/protocol.TransferContract"},
+		// Unknown names all decode to the same type, keeping the name.
+		{`{"type":"Future0Contract","parameter":{"type_url":"type.googleapis.com/protocol.Future0Contract","value":{}}}`, UnknownContract, "Future0Contract", "type.googleapis.com/protocol.Future0Contract"},
+		{`{"type":"Future1Contract","parameter":{"type_url":"type.googleapis.com/protocol.Future1Contract","value":{}}}`, UnknownContract, "Future1Contract", "type.googleapis.com/protocol.Future1Contract"},
+		{`{"type":70,"parameter":{"type_url":"type.googleapis.com/protocol.Future2Contract","value":{}}}`, ContractType(70), "", "type.googleapis.com/protocol.ContractType(70)"},
+	}
+	for _, tt := range tests {
+		var c Contract
+		if err := json.Unmarshal([]byte(tt.json), &c); err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if c.Type != tt.typ || c.TypeName != tt.typeName || c.typeURL() != tt.typeURL {
+			t.Errorf("%s: decoded %d %q %s, want %d %q %s", tt.json, c.Type, c.TypeName, c.typeURL(), tt.typ, tt.typeName, tt.typeURL)
+		}
+
+		// The name of an unknown type is written back.
+		b, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Contract
+		if err := json.Unmarshal(b, &back); err != nil || back.Type != c.Type || back.TypeName != c.TypeName {
+			t.Errorf("%s: round trip = %s", tt.json, b)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package tronhttpClient
+
+import "encoding/json"
+
+// ContractParameter is the google.protobuf.Any parameter of a contract.
+// Value holds a pointer to the *Value struct registered for TypeURL, e.g.
+// *TransferContractValue, or a json.RawMessage for unknown types.
+type ContractParameter struct {
+	TypeURL string      `json:"type_url"`
+	Value   interface{} `json:"value"`
+}
+
+// contractValues returns a new value for the parameters of each known
+// contract type.
+var contractValues = map[ContractType]func() interface{}{
+	AccountCreateContract:           func() interface{} { return new(AccountCreateContractValue) },
+	TransferContract:                func() interface{} { return new(TransferContractValue) },
+	TransferAssetContract:           func() interface{} { return new(TransferAssetContractValue) },
+	VoteWitnessContract:             func() interface{} { return new(VoteWitnessContractValue) },
+	ParticipateAssetIssueContract:   func() interface{} { return new(ParticipateAssetIssueContractValue) },
+	AccountUpdateContract:           func() interface{} { return new(AccountUpdateContractValue) },
+	FreezeBalanceContract:           func() interface{} { return new(FreezeBalanceContractValue) },
+	UnfreezeBalanceContract:         func() interface{} { return new(UnfreezeBalanceContractValue) },
+	WithdrawBalanceContract:         func() interface{} { return new(WithdrawBalanceContractValue) },
+	TriggerSmartContract:            func() interface{} { return new(TriggerSmartContractValue) },
+	FreezeBalanceV2Contract:         func() interface{} { return new(FreezeBalanceV2ContractValue) },
+	UnfreezeBalanceV2Contract:       func() interface{} { return new(UnfreezeBalanceV2ContractValue) },
+	WithdrawExpireUnfreezeContract:  func() interface{} { return new(WithdrawExpireUnfreezeContractValue) },
+	DelegateResourceContract:        func() interface{} { return new(DelegateResourceContractValue) },
+	UnDelegateResourceContract:      func() interface{} { return new(UnDelegateResourceContractValue) },
+	CancelAllUnfreezeV2Contract:     func() interface{} { return new(CancelAllUnfreezeV2ContractValue) },
+	AccountPermissionUpdateContract: func() interface{} { return new(AccountPermissionUpdateContractValue) },
+	AssetIssueContract:              func() interface{} { return new(AssetIssue) },
+	UpdateAssetContract:             func() interface{} { return new(UpdateAssetContractValue) },
+	UnfreezeAssetContract:           func() interface{} { return new(UnfreezeAssetContractValue) },
+	CreateSmartContract:             func() interface{} { return new(CreateSmartContractValue) },
+	UpdateSettingContract:           func() interface{} { return new(UpdateSettingContractValue) },
+	UpdateEnergyLimitContract:       func() interface{} { return new(UpdateEnergyLimitContractValue) },
+	ClearABIContract:                func() interface{} { return new(ClearABIContractValue) },
+}
+
+// UnmarshalJSON decodes the value in the struct registered for the type_url,
+// falling back to json.RawMessage for unknown types.
+func (p *ContractParameter) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		TypeURL string          `json:"type_url"`
+		Value   json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	p.TypeURL = raw.TypeURL
+	p.Value = raw.Value
+
+	t, ok := ContractTypeFromTypeURL(raw.TypeURL)
+	if !ok {
+		return nil
+	}
+	newValue, ok := contractValues[t]
+	if !ok {
+		return nil
+	}
+
+	value := newValue()
+	if err := json.Unmarshal(raw.Value, value); err != nil {
+		return err
+	}
+	p.Value = value
+	return nil
+}
+
+// Addresses in the contract values are hex ("41...") or base58 ("T...")
+// depending on the visible flag of the transaction. Resources are
+// "BANDWIDTH" (omitted by the node, as the protobuf default), "ENERGY" or
+// "TRON_POWER". The pb tags give the protobuf field number and encoding of
+// each field, see encodeMessage.
+
+// AccountCreateContractValue is the parameter of AccountCreateContract.
+type AccountCreateContractValue struct {
+	OwnerAddress   string `json:"owner_address,omitempty" pb:"1,address"`
+	AccountAddress string `json:"account_address,omitempty" pb:"2,address"`
+	Type           string `json:"type,omitempty" pb:"3,account_type"`
+}
+
+// TransferContractValue is the parameter of TransferContract, a TRX transfer.
+type TransferContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+	ToAddress    string `json:"to_address,omitempty" pb:"2,address"`
+	Amount       Sun    `json:"amount,omitempty" pb:"3,varint"`
+}
+
+// TransferAssetContractValue is the parameter of TransferAssetContract,
+// a TRC10 transfer. AssetName is the hex encoded token id.
+type TransferAssetContractValue struct {
+	AssetName    string `json:"asset_name,omitempty" pb:"1,name"`
+	OwnerAddress string `json:"owner_address,omitempty" pb:"2,address"`
+	ToAddress    string `json:"to_address,omitempty" pb:"3,address"`
+	Amount       int64  `json:"amount,omitempty" pb:"4,varint"`
+}
+
+// Vote is a vote for a super representative candidate.
+type Vote struct {
+	VoteAddress string `json:"vote_address,omitempty" pb:"1,address"`
+	VoteCount   int64  `json:"vote_count,omitempty" pb:"2,varint"`
+}
+
+// VoteWitnessContractValue is the parameter of VoteWitnessContract.
+type VoteWitnessContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+	Votes        []Vote `json:"votes,omitempty" pb:"2,message"`
+	Support      bool   `json:"support,omitempty" pb:"3,varint"`
+}
+
+// ParticipateAssetIssueContractValue is the parameter of
+// ParticipateAssetIssueContract. Amount is the TRX spent buying the asset.
+type ParticipateAssetIssueContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+	ToAddress    string `json:"to_address,omitempty" pb:"2,address"`
+	AssetName    string `json:"asset_name,omitempty" pb:"3,name"`
+	Amount       Sun    `json:"amount,omitempty" pb:"4,varint"`
+}
+
+// AccountUpdateContractValue is the parameter of AccountUpdateContract.
+// AccountName is hex encoded.
+type AccountUpdateContractValue struct {
+	AccountName  string `json:"account_name,omitempty" pb:"1,name"`
+	OwnerAddress string `json:"owner_address,omitempty" pb:"2,address"`
+}
+
+// FreezeBalanceContractValue is the parameter of the deprecated (stake 1.0)
+// FreezeBalanceContract.
+type FreezeBalanceContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	FrozenBalance   Sun    `json:"frozen_balance,omitempty" pb:"2,varint"`
+	FrozenDuration  int64  `json:"frozen_duration,omitempty" pb:"3,varint"`
+	Resource        string `json:"resource,omitempty" pb:"10,resource"`
+	ReceiverAddress string `json:"receiver_address,omitempty" pb:"15,address"`
+}
+
+// UnfreezeBalanceContractValue is the parameter of the deprecated
+// (stake 1.0) UnfreezeBalanceContract.
+type UnfreezeBalanceContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	Resource        string `json:"resource,omitempty" pb:"10,resource"`
+	ReceiverAddress string `json:"receiver_address,omitempty" pb:"13,address"`
+}
+
+// WithdrawBalanceContractValue is the parameter of WithdrawBalanceContract,
+// the claim of voting rewards.
+type WithdrawBalanceContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+}
+
+// TriggerSmartContractValue is the parameter of TriggerSmartContract.
+// Data is the hex encoded ABI call.
+type TriggerSmartContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	ContractAddress string `json:"contract_address,omitempty" pb:"2,address"`
+	CallValue       Sun    `json:"call_value,omitempty" pb:"3,varint"`
+	Data            string `json:"data,omitempty" pb:"4,bytes"`
+	CallTokenValue  int64  `json:"call_token_value,omitempty" pb:"5,varint"`
+	TokenID         int64  `json:"token_id,omitempty" pb:"6,varint"`
+}
+
+// FreezeBalanceV2ContractValue is the parameter of FreezeBalanceV2Contract.
+type FreezeBalanceV2ContractValue struct {
+	OwnerAddress  string `json:"owner_address,omitempty" pb:"1,address"`
+	FrozenBalance Sun    `json:"frozen_balance,omitempty" pb:"2,varint"`
+	Resource      string `json:"resource,omitempty" pb:"3,resource"`
+}
+
+// UnfreezeBalanceV2ContractValue is the parameter of
+// UnfreezeBalanceV2Contract.
+type UnfreezeBalanceV2ContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	UnfreezeBalance Sun    `json:"unfreeze_balance,omitempty" pb:"2,varint"`
+	Resource        string `json:"resource,omitempty" pb:"3,resource"`
+}
+
+// WithdrawExpireUnfreezeContractValue is the parameter of
+// WithdrawExpireUnfreezeContract.
+type WithdrawExpireUnfreezeContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+}
+
+// DelegateResourceContractValue is the parameter of DelegateResourceContract.
+type DelegateResourceContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	Resource        string `json:"resource,omitempty" pb:"2,resource"`
+	Balance         Sun    `json:"balance,omitempty" pb:"3,varint"`
+	ReceiverAddress string `json:"receiver_address,omitempty" pb:"4,address"`
+	Lock            bool   `json:"lock,omitempty" pb:"5,varint"`
+	LockPeriod      int64  `json:"lock_period,omitempty" pb:"6,varint"`
+}
+
+// UnDelegateResourceContractValue is the parameter of
+// UnDelegateResourceContract.
+type UnDelegateResourceContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	Resource        string `json:"resource,omitempty" pb:"2,resource"`
+	Balance         Sun    `json:"balance,omitempty" pb:"3,varint"`
+	ReceiverAddress string `json:"receiver_address,omitempty" pb:"4,address"`
+}
+
+// CancelAllUnfreezeV2ContractValue is the parameter of
+// CancelAllUnfreezeV2Contract.
+type CancelAllUnfreezeV2ContractValue struct {
+	OwnerAddress string `json:"owner_address,omitempty" pb:"1,address"`
+}
+
+// AccountPermissionUpdateContractValue is the parameter of
+// AccountPermissionUpdateContract.
+type AccountPermissionUpdateContractValue struct {
+	OwnerAddress string       `json:"owner_address,omitempty" pb:"1,address"`
+	Owner        *Permission  `json:"owner,omitempty" pb:"2,message"`
+	Witness      *Permission  `json:"witness,omitempty" pb:"3,message"`
+	Actives      []Permission `json:"actives,omitempty" pb:"4,message"`
+}
@@ -0,0 +1,106 @@
+package tronhttpClient
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultGapLimit is the BIP44 address gap limit: wallets stop scanning after
+// this many consecutive unused addresses.
+const DefaultGapLimit = 20
+
+// ErrGapLimit is returned by DepositAllocator.Next when handing out one more
+// address would exceed the gap limit.
+var ErrGapLimit = errors.New("deposit: gap limit reached")
+
+// DepositAllocator hands out watch-only deposit addresses derived from an
+// extended public key, without ever holding a private key. Addresses are the
+// non-hardened children key/index, so key is usually the external chain of
+// an account, m/44'/195'/account'/0, neutered.
+//
+// The allocator refuses to hand out more than GapLimit consecutive addresses
+// that have not been marked as used, so that every allocated address is still
+// found by wallets recovering the account from its seed.
+type DepositAllocator struct {
+	mu       sync.Mutex
+	key      *ExtendedKey
+	gapLimit uint32
+	next     uint32 // next index to hand out
+	used     uint32 // lowest index after the last used address
+}
+
+// NewDepositAllocator returns a DepositAllocator for the children of key.
+// next is the first index to hand out and used the index following the last
+// address marked as used, both 0 for a fresh key. A zero gapLimit means
+// DefaultGapLimit.
+func NewDepositAllocator(key *ExtendedKey, gapLimit, next, used uint32) *DepositAllocator {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	if used > next {
+		next = used
+	}
+	return &DepositAllocator{key: key.Neuter(), gapLimit: gapLimit, next: next, used: used}
+}
+
+// Next returns the next unallocated index and its address.
+func (a *DepositAllocator) Next() (uint32, *AddressWithoutPrivKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for {
+		if a.next-a.used >= a.gapLimit {
+			return 0, nil, ErrGapLimit
+		}
+		if a.next >= HardenedKeyStart {
+			return 0, nil, ErrInvalidChild
+		}
+
+		index := a.next
+		a.next++
+
+		addr, err := a.address(index)
+		if err == ErrInvalidChild {
+			continue // BIP32 says to skip to the next index.
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		return index, addr, nil
+	}
+}
+
+// MarkUsed records that the address at index received funds, which moves the
+// gap window forward.
+func (a *DepositAllocator) MarkUsed(index uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if index >= a.used {
+		a.used = index + 1
+	}
+	if a.used > a.next {
+		a.next = a.used
+	}
+}
+
+// AddressAt returns the address at index, allocated or not.
+func (a *DepositAllocator) AddressAt(index uint32) (*AddressWithoutPrivKey, error) {
+	return a.address(index)
+}
+
+// State returns the next index to hand out and the index following the last
+// used address, to be persisted and given back to NewDepositAllocator.
+func (a *DepositAllocator) State() (next, used uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.next, a.used
+}
+
+func (a *DepositAllocator) address(index uint32) (*AddressWithoutPrivKey, error) {
+	child, err := a.key.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	return child.PublicAddress()
+}
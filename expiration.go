@@ -0,0 +1,64 @@
+package tronhttpClient
+
+import (
+	"fmt"
+	"time"
+)
+
+// TxExpiredError is returned when signing or broadcasting a transaction
+// whose expiration has passed. The node would reject it with
+// TRANSACTION_EXPIRATION_ERROR.
+type TxExpiredError struct {
+	TxID       string
+	Expiration time.Time
+}
+
+func (e *TxExpiredError) Error() string {
+	return fmt.Sprintf("transaction %s expired at %s", e.TxID, e.Expiration.Format(time.RFC3339))
+}
+
+// msToTime converts a node timestamp in milliseconds to a time.Time.
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// ExpiresAt returns the expiration of tx, read from its raw data.
+func (tx *Transaction) ExpiresAt() time.Time {
+	return msToTime(tx.RawData.Expiration)
+}
+
+// TimeRemaining returns the time left before tx expires, negative when it
+// has already expired.
+func (tx *Transaction) TimeRemaining() time.Duration {
+	return time.Until(tx.ExpiresAt())
+}
+
+// IsExpired reports whether tx has expired. Transactions without
+// expiration never expire.
+func (tx *Transaction) IsExpired() bool {
+	return tx.RawData.Expiration != 0 && tx.TimeRemaining() <= 0
+}
+
+// checkExpiration returns a *TxExpiredError if tx has expired.
+func checkExpiration(tx *Transaction) error {
+	if tx.IsExpired() {
+		return &TxExpiredError{TxID: tx.TxId, Expiration: tx.ExpiresAt()}
+	}
+	return nil
+}
+
+// ExtendExpiration sets the expiration of the unsigned tx to d from now and
+// recomputes its RawDataHex and TxId. d is limited to MaxExpiration, and the
+// reference block of tx must still be one of the last 65536 blocks for the
+// node to accept it.
+func (tx *Transaction) ExtendExpiration(d time.Duration) error {
+	if d <= 0 || d > MaxExpiration {
+		return ErrInvalidExpiration
+	}
+	if len(tx.Signature) > 0 {
+		return ErrSignedTx
+	}
+
+	tx.RawData.Expiration = time.Now().Add(d).UnixNano() / int64(time.Millisecond)
+	return tx.UpdateRawDataHex()
+}
@@ -2,4 +2,8 @@ module github.com/stdevHsequeda/TRONHttpClient
 
 go 1.14
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v3 v3.0.0
+	github.com/stretchr/testify v1.6.1
+	golang.org/x/crypto v0.0.0-20200604202706-70a84ac30bf9
+)
@@ -0,0 +1,345 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// HardenedKeyStart is the index of the first hardened BIP32 child key.
+const HardenedKeyStart uint32 = 0x80000000
+
+// TronCoinType is the SLIP-44 coin type registered for TRON.
+const TronCoinType = 195
+
+var (
+	// ErrInvalidSeed is returned when the seed length is outside [16, 64] bytes.
+	ErrInvalidSeed = errors.New("bip32: seed length must be between 128 and 512 bits")
+	// ErrUnusableSeed is returned in the (very unlikely) case the seed yields
+	// an invalid master key.
+	ErrUnusableSeed = errors.New("bip32: unusable seed")
+	// ErrInvalidChild is returned in the (very unlikely) case a child index
+	// yields an invalid key, the next index should be used instead.
+	ErrInvalidChild = errors.New("bip32: invalid child, use the next index")
+	// ErrDeriveHardenedFromPublic is returned when a hardened child is
+	// derived from a public extended key.
+	ErrDeriveHardenedFromPublic = errors.New("bip32: cannot derive a hardened key from a public key")
+	// ErrNotPrivate is returned when a private key is requested from a public
+	// extended key.
+	ErrNotPrivate = errors.New("bip32: extended key is not private")
+	// ErrInvalidPath is returned when a derivation path can not be parsed.
+	ErrInvalidPath = errors.New("bip32: invalid derivation path")
+)
+
+// ExtendedKey is a BIP32 extended private or public key.
+type ExtendedKey struct {
+	key       []byte // 32 bytes private key or 33 bytes compressed public key
+	chainCode []byte
+	depth     uint8
+	parentFP  []byte
+	childNum  uint32
+	private   bool
+}
+
+// NewMasterKey returns the BIP32 master private key of seed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeed
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(i[:32]); overflow || k.IsZero() {
+		return nil, ErrUnusableSeed
+	}
+
+	return &ExtendedKey{
+		key:       i[:32],
+		chainCode: i[32:],
+		parentFP:  []byte{0, 0, 0, 0},
+		private:   true,
+	}, nil
+}
+
+// TronPath returns the BIP44 derivation path m/44'/195'/account'/0/index.
+func TronPath(account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", TronCoinType, account, index)
+}
+
+// DeriveAddress derives the TRON Address at m/44'/195'/account'/0/index
+// of seed.
+func DeriveAddress(seed []byte, account, index uint32) (*Address, error) {
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := master.DerivePath(TronPath(account, index))
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Address()
+}
+
+// IsPrivate reports whether k is an extended private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private
+}
+
+// Depth returns the depth of k in the derivation tree, 0 for master keys.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index k was derived with.
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNum
+}
+
+// PublicKey returns the 33 bytes compressed public key of k.
+func (k *ExtendedKey) PublicKey() []byte {
+	if !k.private {
+		return append([]byte{}, k.key...)
+	}
+	return secp256k1.PrivKeyFromBytes(k.key).PubKey().SerializeCompressed()
+}
+
+// Child derives the child key of k at index i. Indexes from HardenedKeyStart
+// on derive hardened keys, which require k to be private.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	hardened := i >= HardenedKeyStart
+	if hardened && !k.private {
+		return nil, ErrDeriveHardenedFromPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.PublicKey()...)
+	}
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[len(data)-4:], i)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var il secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(sum[:32]); overflow {
+		return nil, ErrInvalidChild
+	}
+
+	child := &ExtendedKey{
+		chainCode: sum[32:],
+		depth:     k.depth + 1,
+		parentFP:  k.fingerprint(),
+		childNum:  i,
+		private:   k.private,
+	}
+
+	if k.private {
+		// child = IL + k (mod n)
+		var parent secp256k1.ModNScalar
+		parent.SetByteSlice(k.key)
+		il.Add(&parent)
+		if il.IsZero() {
+			return nil, ErrInvalidChild
+		}
+		b := il.Bytes()
+		child.key = b[:]
+		return child, nil
+	}
+
+	// child = point(IL) + K
+	pub, err := secp256k1.ParsePubKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentPoint, ilPoint, result secp256k1.JacobianPoint
+	pub.AsJacobian(&parentPoint)
+	secp256k1.ScalarBaseMultNonConst(&il, &ilPoint)
+	secp256k1.AddNonConst(&ilPoint, &parentPoint, &result)
+	if (result.X.IsZero() && result.Y.IsZero()) || result.Z.IsZero() {
+		return nil, ErrInvalidChild
+	}
+	result.ToAffine()
+	child.key = secp256k1.NewPublicKey(&result.X, &result.Y).SerializeCompressed()
+	return child, nil
+}
+
+// DerivePath derives the descendant of k at path, for instance
+// "m/44'/195'/0'/0/0". Hardened indexes are marked with ' or h.
+// A path starting with "m" requires k to be a master key, otherwise the path
+// is relative to k.
+func (k *ExtendedKey) DerivePath(path string) (*ExtendedKey, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] == "m" {
+		if k.depth != 0 {
+			return nil, ErrInvalidPath
+		}
+		parts = parts[1:]
+	}
+
+	key := k
+	for _, p := range parts {
+		if p == "" {
+			return nil, ErrInvalidPath
+		}
+
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") || strings.HasSuffix(p, "H") {
+			offset = HardenedKeyStart
+			p = p[:len(p)-1]
+		}
+
+		idx, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || uint32(idx) >= HardenedKeyStart {
+			return nil, ErrInvalidPath
+		}
+
+		key, err = key.Child(uint32(idx) + offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// Address returns the TRON Address of k, including its hex private key.
+func (k *ExtendedKey) Address() (*Address, error) {
+	if !k.private {
+		return nil, ErrNotPrivate
+	}
+	return newAddress(secp256k1.PrivKeyFromBytes(k.key)), nil
+}
+
+// fingerprint returns the first 4 bytes of HASH160 of the public key of k.
+func (k *ExtendedKey) fingerprint() []byte {
+	sha := sha256.Sum256(k.PublicKey())
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)[:4]
+}
+
+// Version bytes of the BIP32 serialization format.
+var (
+	xprvVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+)
+
+// ErrInvalidExtendedKey is returned when a serialized extended key can not
+// be parsed.
+var ErrInvalidExtendedKey = errors.New("bip32: invalid extended key")
+
+// Neuter returns the extended public key of k.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	return &ExtendedKey{
+		key:       k.PublicKey(),
+		chainCode: k.chainCode,
+		depth:     k.depth,
+		parentFP:  k.parentFP,
+		childNum:  k.childNum,
+	}
+}
+
+// PublicAddress returns the TRON address of k without its private key. It
+// works on both private and public extended keys.
+func (k *ExtendedKey) PublicAddress() (*AddressWithoutPrivKey, error) {
+	pub, err := secp256k1.ParsePubKey(k.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	addr := addressFromPublicKey(pub)
+	return &AddressWithoutPrivKey{
+		Base58CheckAddress: address.ToBase58(addr),
+		Value:              address.ToHex(addr),
+	}, nil
+}
+
+// String returns the BIP32 serialization of k ("xprv..." or "xpub...").
+func (k *ExtendedKey) String() string {
+	b := make([]byte, 0, 78)
+	if k.private {
+		b = append(b, xprvVersion...)
+	} else {
+		b = append(b, xpubVersion...)
+	}
+	b = append(b, k.depth)
+	b = append(b, k.parentFP...)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], k.childNum)
+	b = append(b, k.chainCode...)
+	if k.private {
+		b = append(b, 0x00)
+	}
+	b = append(b, k.key...)
+
+	return address.EncodeBase58Check(b)
+}
+
+// ParseExtendedKey parses a BIP32 serialized extended key, either private
+// ("xprv...") or public ("xpub...").
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	b, err := address.DecodeBase58Check(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 78 {
+		return nil, ErrInvalidExtendedKey
+	}
+
+	k := &ExtendedKey{
+		depth:     b[4],
+		parentFP:  b[5:9],
+		childNum:  binary.BigEndian.Uint32(b[9:13]),
+		chainCode: b[13:45],
+	}
+
+	switch {
+	case bytes.Equal(b[:4], xprvVersion):
+		if b[45] != 0x00 {
+			return nil, ErrInvalidExtendedKey
+		}
+		var sk secp256k1.ModNScalar
+		if overflow := sk.SetByteSlice(b[46:]); overflow || sk.IsZero() {
+			return nil, ErrInvalidExtendedKey
+		}
+		k.key = b[46:]
+		k.private = true
+	case bytes.Equal(b[:4], xpubVersion):
+		if _, err := secp256k1.ParsePubKey(b[45:]); err != nil {
+			return nil, ErrInvalidExtendedKey
+		}
+		k.key = b[45:]
+	default:
+		return nil, ErrInvalidExtendedKey
+	}
+
+	if k.depth == 0 && (k.childNum != 0 || !bytes.Equal(k.parentFP, []byte{0, 0, 0, 0})) {
+		return nil, ErrInvalidExtendedKey
+	}
+
+	return k, nil
+}
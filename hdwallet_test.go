@@ -0,0 +1,113 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// checkSerialized compares k with the fields of the serialized extended key s:
+// version, depth, parent fingerprint, child number, chain code and key.
+func checkSerialized(t *testing.T, k *ExtendedKey, s string) {
+	t.Helper()
+	b, err := address.DecodeBase58Check(s)
+	if err != nil || len(b) != 78 {
+		t.Fatalf("DecodeBase58Check(%s) = %x, %v", s, b, err)
+	}
+
+	key := k.PublicKey()
+	if b[45] == 0 {
+		key = append([]byte{0}, k.key...)
+	}
+	if b[4] != k.Depth() ||
+		!bytes.Equal(b[5:9], k.parentFP) ||
+		binary.BigEndian.Uint32(b[9:13]) != k.ChildNumber() ||
+		!bytes.Equal(b[13:45], k.chainCode) ||
+		!bytes.Equal(b[45:], key) {
+		t.Errorf("key at depth %d does not match %s", k.Depth(), s)
+	}
+}
+
+// Test vector 1 of BIP32.
+// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#test-vector-1
+func TestDerivePathBIP32(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		xpub string
+		xprv string
+	}{
+		{
+			"m",
+			"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+			"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		},
+		{
+			"m/0'",
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+			"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+		},
+		{
+			"m/0'/1/2'/2",
+			"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+			"xprvA2JDeKCSNNZky6uBCviVfJSKyQ1mDYahRjijr5idH2WwLsEd4Hsb2Tyh8RfQMuPh7f7RtyzTtdrbdqqsunu5Mm3wDvUAKRHSC34sJ7in334",
+		},
+	}
+	for _, tt := range tests {
+		k, err := master.DerivePath(tt.path)
+		if err != nil {
+			t.Fatalf("DerivePath(%s): %v", tt.path, err)
+		}
+		checkSerialized(t, k, tt.xprv)
+		checkSerialized(t, k, tt.xpub)
+
+		if got := k.String(); got != tt.xprv {
+			t.Errorf("%s xprv = %s, want %s", tt.path, got, tt.xprv)
+		}
+		if got := k.Neuter().String(); got != tt.xpub {
+			t.Errorf("%s xpub = %s, want %s", tt.path, got, tt.xpub)
+		}
+
+		parsed, err := ParseExtendedKey(tt.xpub)
+		if err != nil || parsed.String() != tt.xpub {
+			t.Errorf("ParseExtendedKey(%s) = %v, %v", tt.xpub, parsed, err)
+		}
+	}
+}
+
+// Addresses of the mnemonic "abandon ... about" along the TRON path
+// m/44'/195'/account'/0/index.
+func TestDeriveAddress(t *testing.T) {
+	seed := NewSeed(bip39Vectors[0].mnemonic, "")
+
+	tests := []struct {
+		account, index uint32
+		path           string
+		privateKey     string
+		address        string
+	}{
+		{0, 0, "m/44'/195'/0'/0/0", "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28", "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH"},
+		{0, 1, "m/44'/195'/0'/0/1", "edb728e259afca2ddcc428459e7681b8414668649aedbc8d25c0872da219b2e6", "TSeJkUh4Qv67VNFwY8LaAxERygNdy6NQZK"},
+	}
+	for _, tt := range tests {
+		if got := TronPath(tt.account, tt.index); got != tt.path {
+			t.Errorf("TronPath(%d, %d) = %s, want %s", tt.account, tt.index, got, tt.path)
+		}
+
+		addr, err := DeriveAddress(seed, tt.account, tt.index)
+		if err != nil {
+			t.Fatalf("DeriveAddress(%s): %v", tt.path, err)
+		}
+		if addr.PrivateKey != tt.privateKey || addr.Address != tt.address {
+			t.Errorf("DeriveAddress(%s) = %s %s, want %s %s", tt.path, addr.PrivateKey, addr.Address, tt.privateKey, tt.address)
+		}
+	}
+}
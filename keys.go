@@ -0,0 +1,57 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// ErrInvalidPrivateKey is returned when a private key is not a valid 32 bytes
+// secp256k1 scalar.
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// parsePrivateKey decodes a hex encoded secp256k1 private key.
+func parsePrivateKey(privKey string) (*secp256k1.PrivateKey, error) {
+	b, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(b); overflow || k.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+	return secp256k1.NewPrivateKey(&k), nil
+}
+
+// addressFromPublicKey returns the 21 bytes address of pub.
+func addressFromPublicKey(pub *secp256k1.PublicKey) []byte {
+	// SerializeUncompressed always returns a valid 65 bytes key.
+	addr, _ := address.FromPublicKey(pub.SerializeUncompressed())
+	return addr
+}
+
+// newAddress returns the Address of priv.
+func newAddress(priv *secp256k1.PrivateKey) *Address {
+	addr := addressFromPublicKey(priv.PubKey())
+	return &Address{
+		PrivateKey: hex.EncodeToString(priv.Serialize()),
+		Address:    address.ToBase58(addr),
+		HexAddress: address.ToHex(addr),
+	}
+}
+
+// AddressFromPrivateKey returns the Address of the hex encoded private key,
+// computed locally.
+func AddressFromPrivateKey(privKey string) (*Address, error) {
+	priv, err := parsePrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return newAddress(priv), nil
+}
@@ -2,64 +2,124 @@ package tronhttpClient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	httpClient "github.com/stdevHsequeda/TRONHttpClient/client"
 	"net/http"
+	"strings"
 )
 
-const testNet = "https://api.shasta.trongrid.io"
-const mainNet = "https://api.trongrid.io"
+// Base URLs of the public TronGrid nodes, to be passed to NewClient.
+const (
+	// MainNet is the TronGrid node of the TRON mainnet.
+	MainNet = "https://api.trongrid.io"
+	// TestNet is the TronGrid node of the Shasta testnet.
+	TestNet = "https://api.shasta.trongrid.io"
+)
 
 type Client struct {
 	client  *httpClient.Client
 	network string
+	// solidityNode is the base URL of the /walletsolidity endpoints.
+	solidityNode string
 }
 
-// NewClient returns a new instance of Client
+// NewClient returns a new instance of Client querying the full node at the
+// base URL network, e.g. MainNet, TestNet or the URL of a private node. The
+// network argument used to be ignored; it is now the base URL of every
+// request, and TestNet is used when it is empty. The solidity node defaults
+// to the same URL, see SetSolidityNode.
 func NewClient(network string) *Client {
 	httpClient.MaxRetry = 5
-	return &Client{client: httpClient.NewClient(), network: network}
+	if network == "" {
+		network = TestNet
+	}
+	network = strings.TrimRight(network, "/")
+	return &Client{client: httpClient.NewClient(), network: network, solidityNode: network}
+}
+
+// SetSolidityNode sets the base URL of the solidity node serving the
+// /walletsolidity endpoints, for nodes not serving both APIs.
+func (c *Client) SetSolidityNode(url string) {
+	c.solidityNode = strings.TrimRight(url, "/")
 }
 
 // CreateTx Create a TRX transfer transaction.
 // If toAddr does not exist, then create the account on the blockchain.
-func (c *Client) CreateTx(toAddr, ownerAddr string, amount int) (*Transaction, error) {
-	encodeData, err := json.Marshal(
-		map[string]interface{}{
-			"to_address":    toAddr,
-			"owner_address": ownerAddr,
-			"amount":        amount,
-		})
-	if err != nil {
-		return nil, err
-	}
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) CreateTx(toAddr, ownerAddr string, amount Sun) (*Transaction, error) {
+	return c.CreateTxWithOptions(toAddr, ownerAddr, amount, nil)
+}
 
-	req, err := http.NewRequest("POST", testNet+"/wallet/createtransaction",
-		bytes.NewBuffer(encodeData))
-	if err != nil {
-		return nil, err
+// TxOptions are the optional fields of a transfer transaction.
+type TxOptions struct {
+	// Memo is stored in the data field of the raw data, it is what exchanges
+	// use to match deposits.
+	Memo string
+	// PermissionID is the id of the permission signing the transaction,
+	// 0 (owner) by default.
+	PermissionID int
+	// FeeLimit is the maximum fee the transaction may burn.
+	FeeLimit Sun
+	// Visible formats the addresses of the transaction in base58 instead of
+	// hex. The given addresses must use the same format.
+	Visible bool
+}
+
+// CreateTxWithOptions Create a TRX transfer transaction with a memo, a permission
+// id, a fee limit or visible addresses. The memo and fee limit are set locally,
+// then raw_data_hex and txID are recomputed.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) CreateTxWithOptions(toAddr, ownerAddr string, amount Sun, opts *TxOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &TxOptions{}
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.client.CallRetryable(req)
+	params := map[string]interface{}{
+		"to_address":    toAddr,
+		"owner_address": ownerAddr,
+		"amount":        amount,
+		"visible":       opts.Visible,
+	}
+	if opts.PermissionID != 0 {
+		params["Permission_id"] = opts.PermissionID
+	}
+	tx, err := c.createTx("/wallet/createtransaction", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var tx Transaction
-	err = json.NewDecoder(resp).Decode(&tx)
-	if err != nil {
-		return nil, err
+	if opts.Memo != "" || opts.FeeLimit != 0 {
+		tx.RawData.SetMemo(opts.Memo)
+		tx.RawData.FeeLimit = opts.FeeLimit
+		if err := tx.UpdateRawDataHex(); err != nil {
+			return nil, err
+		}
 	}
 
-	return &tx, err
+	return tx, nil
 }
 
 // GetTxSign Sign the transaction, the api has the risk of leaking the private key,
-// please make sure to call the api in a secure environment
+// please make sure to call the api in a secure environment.
+// The transaction is verified with VerifyTx before being sent, and expired
+// transactions are refused with a *TxExpiredError.
 func (c *Client) GetTxSign(tx *Transaction, privKey string) (*Transaction, error) {
+	return c.GetTxSignWithOptions(tx, privKey, nil)
+}
+
+// GetTxSignWithOptions is GetTxSign, with the transactions VerifyTx can not
+// fully verify sent when opts allows it.
+func (c *Client) GetTxSignWithOptions(tx *Transaction, privKey string, opts *SignOptions) (*Transaction, error) {
+	if err := verifyForSigning(tx, opts); err != nil {
+		return nil, err
+	}
+	if err := checkExpiration(tx); err != nil {
+		return nil, err
+	}
+
 	encodeData, err := json.Marshal(
 		struct {
 			Transaction *Transaction `json:"transaction"`
@@ -72,7 +132,7 @@ func (c *Client) GetTxSign(tx *Transaction, privKey string) (*Transaction, error
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", testNet+"/wallet/gettransactionsign",
+	req, err := http.NewRequest("POST", c.network+"/wallet/gettransactionsign",
 		bytes.NewBuffer(encodeData))
 	if err != nil {
 		return nil, err
@@ -92,37 +152,36 @@ func (c *Client) GetTxSign(tx *Transaction, privKey string) (*Transaction, error
 	return tx, err
 }
 
-// BroadcastTx  Broadcast the signed transaction
+// BroadcastTx  Broadcast the signed transaction.
+// Expired transactions are refused with a *TxExpiredError, and transactions
+// refused by the node, including the ones it already has, with a
+// *BroadcastError.
 func (c *Client) BroadcastTx(tx *Transaction) (*Transaction, error) {
-	encodeData, err := json.Marshal(tx)
-	if err != nil {
+	if err := c.broadcastTx(context.Background(), tx); err != nil {
 		return nil, err
 	}
+	return tx, nil
+}
 
-	req, err := http.NewRequest("POST", testNet+"/wallet/broadcasttransaction",
-		bytes.NewBuffer(encodeData))
-	if err != nil {
-		return nil, err
+func (c *Client) broadcastTx(ctx context.Context, tx *Transaction) error {
+	if err := checkExpiration(tx); err != nil {
+		return err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.client.CallRetryable(req)
-	if err != nil {
-		return nil, err
+	var res broadcastResult
+	if err := c.postContext(ctx, "/wallet/broadcasttransaction", tx, &res); err != nil {
+		return err
 	}
-
-	err = json.NewDecoder(resp).Decode(tx)
-	if err != nil {
-		return nil, err
+	if !res.Result {
+		return &BroadcastError{TxID: tx.TxId, Code: res.Code, Message: decodeHexText(res.Message)}
 	}
-
-	return tx, nil
+	return nil
 }
 
 // GenerateAddress Generates a random private key and address pair. Returns a private key,
 // the corresponding address in hex, and base58.
 func (c *Client) GenerateAddress() (*Address, error) {
-	req, err := http.NewRequest("GET", testNet+"/wallet/generateaddress",
+	req, err := http.NewRequest("GET", c.network+"/wallet/generateaddress",
 		nil)
 	if err != nil {
 		return nil, err
@@ -152,7 +211,7 @@ func (c *Client) CreateAddress(password string) (*AddressWithoutPrivKey, error)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", testNet+"/wallet/createaddress", bytes.NewBuffer(encodeData))
+	req, err := http.NewRequest("GET", c.network+"/wallet/createaddress", bytes.NewBuffer(encodeData))
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +240,7 @@ func (c *Client) ValidateAddress(address string) (bool, error) {
 		return false, err
 	}
 
-	req, err := http.NewRequest("GET", testNet+"/wallet/validateaddress", bytes.NewBuffer(encodeData))
+	req, err := http.NewRequest("GET", c.network+"/wallet/validateaddress", bytes.NewBuffer(encodeData))
 	if err != nil {
 		return false, err
 	}
@@ -193,50 +252,50 @@ func (c *Client) ValidateAddress(address string) (bool, error) {
 	}
 
 	var addr struct {
-		ok bool `json:"result"`
+		Ok bool `json:"result"`
 	}
 	err = json.NewDecoder(resp).Decode(&addr)
 	if err != nil {
 		return false, err
 	}
 
-	return addr.ok, nil
+	return addr.Ok, nil
 }
 
-// BroadcastHex Broadcast the protobuf encoded transaction hex string after sign
+// BroadcastHex Broadcast the protobuf encoded transaction hex string after sign.
+// The txID returned by the node is checked against txHex, expired
+// transactions are refused with a *TxExpiredError and transactions refused
+// by the node are reported with a *BroadcastError.
 func (c *Client) BroadcastHex(txHex string) (*Transaction, error) {
-	encodeData, err := json.Marshal(
-		map[string]string{
-			"transaction": txHex,
-		})
+	tx, err := TransactionFromHex(txHex, false)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", testNet+"/wallet/broadcasthex",
-		bytes.NewBuffer(encodeData))
-	if err != nil {
+	if err := checkExpiration(tx); err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.client.CallRetryable(req)
+	var res broadcastResult
+	err = c.post("/wallet/broadcasthex", map[string]string{
+		"transaction": txHex,
+	}, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	var tx Transaction
-	err = json.NewDecoder(resp).Decode(&tx)
-	if err != nil {
+	if !res.Result {
+		// Unlike /wallet/broadcasttransaction, the message is plain text.
+		return nil, &BroadcastError{TxID: tx.TxId, Code: res.Code, Message: res.Message}
+	}
+	if err := verifyTxHex(txHex, res.TxID); err != nil {
 		return nil, err
 	}
 
-	return &tx, nil
+	return tx, nil
 }
 
 // EasyTransfer Easily transfer from an address using the password string.
 // Only works with accounts created from createAddress,integrated getransactionsign and broadcasttransaction.
-func (c *Client) EasyTransfer(password, toAddress string, amount int) (*Transaction, error) {
+func (c *Client) EasyTransfer(password, toAddress string, amount Sun) (*Transaction, error) {
 	encodeData, err := json.Marshal(
 		map[string]interface{}{
 			"passPhrase": hex.EncodeToString([]byte(password)),
@@ -247,7 +306,7 @@ func (c *Client) EasyTransfer(password, toAddress string, amount int) (*Transact
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", testNet+"/wallet/easytransfer",
+	req, err := http.NewRequest("POST", c.network+"/wallet/easytransfer",
 		bytes.NewBuffer(encodeData))
 	if err != nil {
 		return nil, err
@@ -284,7 +343,7 @@ func (c *Client) EasyTransfer(password, toAddress string, amount int) (*Transact
 }
 
 // EasyTransferByPrivate Easily transfer from an address using the private key.
-func (c *Client) EasyTransferByPrivate(privateKey, toAddress string, amount int) (*Transaction, error) {
+func (c *Client) EasyTransferByPrivate(privateKey, toAddress string, amount Sun) (*Transaction, error) {
 	encodeData, err := json.Marshal(
 		map[string]interface{}{
 			"privateKey": privateKey,
@@ -295,7 +354,7 @@ func (c *Client) EasyTransferByPrivate(privateKey, toAddress string, amount int)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", testNet+"/wallet/easytransferbyprivate",
+	req, err := http.NewRequest("POST", c.network+"/wallet/easytransferbyprivate",
 		bytes.NewBuffer(encodeData))
 	if err != nil {
 		return nil, err
@@ -335,67 +394,36 @@ func (c *Client) EasyTransferByPrivate(privateKey, toAddress string, amount int)
 // Note: The expiration time of the http api creation transaction is 1 minute,
 //       so to complete the on-chain, you need to complete gettransactionsign and
 //       broadcasttransaction within 1 minute after the creation.
+// The returned transaction is verified locally with VerifyTx.
 func (c *Client) CreateAccount(ownerAddr, accountAddr string, visible bool, permissionID int) (*Transaction, error) {
-	encodeData, err := json.Marshal(
-		map[string]interface{}{
-			"owner_address":   ownerAddr,
-			"account_address": accountAddr,
-			"visible":         visible,
-			"permission_id":   visible,
-		})
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", testNet+"/wallet/createaccount",
-		bytes.NewBuffer(encodeData))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.client.CallRetryable(req)
-	if err != nil {
-		return nil, err
+	params := map[string]interface{}{
+		"owner_address":   ownerAddr,
+		"account_address": accountAddr,
+		"visible":         visible,
 	}
-
-	var tx Transaction
-	err = json.NewDecoder(resp).Decode(&tx)
-	if err != nil {
-		return nil, err
+	if permissionID != 0 {
+		params["Permission_id"] = permissionID
 	}
-
-	return &tx, err
+	return c.createTx("/wallet/createaccount", params)
 }
 
-// GetAccount Query information about an account,Including balances, freezes, votes and time, etc
-func (c *Client) GetAccount(address string, visible bool) (*Account, error) {
-	encodeData, err := json.Marshal(
-		map[string]interface{}{
-			"address": address,
-			"visible": visible,
-		})
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", testNet+"/wallet/getaccount",
-		bytes.NewBuffer(encodeData))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.client.CallRetryable(req)
-	if err != nil {
-		return nil, err
-	}
+// GetAccount Query information about an account,Including balances, freezes, votes and time, etc.
+// With Solidified the account is read from the solidity node, reflecting
+// only confirmed transactions.
+func (c *Client) GetAccount(address string, visible bool, consistency Consistency) (*Account, error) {
+	return c.getAccount(context.Background(), address, visible, consistency)
+}
 
+func (c *Client) getAccount(ctx context.Context, address string, visible bool, consistency Consistency) (*Account, error) {
 	var account Account
-	err = json.NewDecoder(resp).Decode(&account)
+	err := c.postContext(ctx, readPath("getaccount", consistency), map[string]interface{}{
+		"address": address,
+		"visible": visible,
+	}, &account)
 	if err != nil {
 		return nil, err
 	}
+	account.Visible = visible
 
-	return &account, err
+	return &account, nil
 }
@@ -0,0 +1,78 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// tronMessagePrefix is the TIP-191 prefix of signed messages.
+const tronMessagePrefix = "\x19TRON Signed Message:\n"
+
+// HashMessageV2 returns the TIP-191 hash of message, as computed by
+// TronWeb's signMessageV2: keccak256(prefix || len(message) || message).
+func HashMessageV2(message []byte) []byte {
+	return address.Keccak256(
+		[]byte(tronMessagePrefix),
+		[]byte(strconv.Itoa(len(message))),
+		message,
+	)
+}
+
+// HashMessageLegacy returns the hash of message as computed by the legacy
+// TronWeb trx.sign(hexString): the length in the prefix is always 32,
+// whatever the length of message.
+func HashMessageLegacy(message []byte) []byte {
+	return address.Keccak256([]byte(tronMessagePrefix+"32"), message)
+}
+
+// SignMessageV2 signs message with the hex encoded privKey following
+// TIP-191 (TronWeb signMessageV2 and TronLink). It returns the 0x prefixed
+// hex r || s || v signature, v being 27 or 28.
+func SignMessageV2(message []byte, privKey string) (string, error) {
+	return signMessage(HashMessageV2(message), privKey)
+}
+
+// SignMessageLegacy signs message with the hex encoded privKey as the legacy
+// TronWeb trx.sign(hexString) does.
+func SignMessageLegacy(message []byte, privKey string) (string, error) {
+	return signMessage(HashMessageLegacy(message), privKey)
+}
+
+// VerifyMessageV2 returns the base58 address that signed message with
+// SignMessageV2. The caller must compare it with the expected address.
+func VerifyMessageV2(message []byte, signature string) (string, error) {
+	return recoverMessage(HashMessageV2(message), signature)
+}
+
+// VerifyMessageLegacy returns the base58 address that signed message with
+// SignMessageLegacy. The caller must compare it with the expected address.
+func VerifyMessageLegacy(message []byte, signature string) (string, error) {
+	return recoverMessage(HashMessageLegacy(message), signature)
+}
+
+func signMessage(hash []byte, privKey string) (string, error) {
+	priv, err := parsePrivateKey(privKey)
+	if err != nil {
+		return "", err
+	}
+
+	sig := signHash(priv, hash)
+	sig[64] += 27
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+func recoverMessage(hash []byte, signature string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil {
+		return "", err
+	}
+
+	addr, err := recoverHash(sig, hash)
+	if err != nil {
+		return "", err
+	}
+	return address.ToBase58(addr), nil
+}
@@ -0,0 +1,273 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// Sign weight result codes returned by /wallet/getsignweight.
+const (
+	SignWeightEnough          = "ENOUGH_PERMISSION"
+	SignWeightNotEnough       = "NOT_ENOUGH_PERMISSION"
+	SignWeightSignatureFormat = "SIGNATURE_FORMAT_ERROR"
+	SignWeightComputeAddress  = "COMPUTE_ADDRESS_ERROR"
+	SignWeightPermission      = "PERMISSION_ERROR"
+	SignWeightOther           = "OTHER_ERROR"
+)
+
+var (
+	// ErrPermissionMismatch is returned when the contracts of a multisig
+	// transaction do not use the permission of the container.
+	ErrPermissionMismatch = errors.New("contract permission id does not match the multisig permission")
+	// ErrOwnerMismatch is returned when the contracts of a multisig
+	// transaction are not owned by the owner of the container.
+	ErrOwnerMismatch = errors.New("contract owner does not match the multisig owner")
+	// ErrAlreadySigned is returned when a key signs a multisig transaction a
+	// second time, which the node would reject.
+	ErrAlreadySigned = errors.New("transaction already signed by this key")
+)
+
+// ThresholdNotReachedError is returned by BroadcastMultisig when the
+// signatures do not reach the threshold of the permission yet.
+type ThresholdNotReachedError struct {
+	TxID      string
+	Weight    int64
+	Threshold int64
+	Code      string
+	Message   string
+}
+
+func (e *ThresholdNotReachedError) Error() string {
+	msg := fmt.Sprintf("transaction %s: signatures weight %d has not reached threshold %d",
+		e.TxID, e.Weight, e.Threshold)
+	if e.Code != "" && e.Code != SignWeightNotEnough {
+		msg += fmt.Sprintf(" (%s: %s)", e.Code, e.Message)
+	}
+	return msg
+}
+
+// MultisigTx is a partially signed transaction shared between the signers of
+// a multi-signature permission. It is serialized to JSON with Marshal and
+// read back with ParseMultisigTx, so it can be passed around as a file or a
+// message until enough signatures are collected.
+type MultisigTx struct {
+	// OwnerAddress is the account whose permission signs the transaction.
+	OwnerAddress string `json:"owner_address"`
+	// PermissionID is the id of the permission signing the transaction,
+	// which every contract of the transaction must reference.
+	PermissionID int `json:"permission_id"`
+	// Permission is the signing permission as returned by GetAccount, used to
+	// check the progress offline. ParseMultisigTx replaces it with the
+	// permission given by the caller.
+	Permission *Permission `json:"permission,omitempty"`
+	// Transaction is the transaction and the signatures collected so far.
+	Transaction *Transaction `json:"transaction"`
+}
+
+// NewMultisigTx returns a container for tx, which must have been created
+// with the permission permissionID of owner (see TxOptions.PermissionID).
+// perm is optional and enables offline progress checks with Check.
+func NewMultisigTx(tx *Transaction, owner string, permissionID int, perm *Permission) (*MultisigTx, error) {
+	m := &MultisigTx{
+		OwnerAddress: owner,
+		PermissionID: permissionID,
+		Permission:   perm,
+		Transaction:  tx,
+	}
+	if err := m.verify(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParseMultisigTx decodes a container serialized with Marshal. The
+// transaction is verified with VerifyTx, its contracts must be owned by the
+// owner of the container and use its permission, and its signatures are
+// recovered, so a tampered container is refused.
+// perm is the signing permission as read by the caller from the account of
+// the owner, e.g. with GetAccount and Account.Permission; the permission
+// embedded in the container is not trusted and is replaced by perm.
+func ParseMultisigTx(b []byte, perm *Permission) (*MultisigTx, error) {
+	if perm == nil {
+		return nil, ErrUnknownPermission
+	}
+
+	var m MultisigTx
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if m.Transaction == nil {
+		return nil, errors.New("multisig container without transaction")
+	}
+	if perm.Id != m.PermissionID {
+		return nil, fmt.Errorf("permission %d: %w", perm.Id, ErrPermissionMismatch)
+	}
+	m.Permission = perm
+
+	if err := m.verify(); err != nil {
+		return nil, err
+	}
+	if _, err := RecoverSigners(m.Transaction); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Marshal serializes m to JSON to be shared with the next signer.
+func (m *MultisigTx) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+func (m *MultisigTx) verify() error {
+	if err := VerifyTx(m.Transaction); err != nil {
+		return err
+	}
+	owner, err := address.Decode(m.OwnerAddress)
+	if err != nil {
+		return fmt.Errorf("owner %q: %w", m.OwnerAddress, err)
+	}
+	for i, c := range m.Transaction.RawData.Contract {
+		if c.PermissionID != m.PermissionID {
+			return fmt.Errorf("contract %d: %w", i, ErrPermissionMismatch)
+		}
+		contractOwner, err := c.ownerAddress()
+		if err != nil {
+			return fmt.Errorf("contract %d: %w", i, err)
+		}
+		if !bytes.Equal(contractOwner, owner) {
+			return fmt.Errorf("contract %d: %w", i, ErrOwnerMismatch)
+		}
+	}
+	return nil
+}
+
+// ownerAddress returns the decoded owner_address of the parameter of c.
+func (c *Contract) ownerAddress() ([]byte, error) {
+	b, err := json.Marshal(c.Parameter.Value)
+	if err != nil {
+		return nil, err
+	}
+	var value struct {
+		OwnerAddress string `json:"owner_address"`
+	}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	return address.Decode(value.OwnerAddress)
+}
+
+// Sign appends the signature of the hex encoded privKey to the transaction.
+// A key can only sign once, and expired transactions are refused with a
+// *TxExpiredError.
+func (m *MultisigTx) Sign(privKey string) error {
+	addr, err := AddressFromPrivateKey(privKey)
+	if err != nil {
+		return err
+	}
+
+	signers, err := RecoverSigners(m.Transaction)
+	if err != nil {
+		return err
+	}
+	for _, s := range signers {
+		if s == addr.Address {
+			return ErrAlreadySigned
+		}
+	}
+
+	_, err = SignTx(m.Transaction, privKey)
+	return err
+}
+
+// Signers returns the base58 addresses of the keys that already signed.
+func (m *MultisigTx) Signers() ([]string, error) {
+	return RecoverSigners(m.Transaction)
+}
+
+// Check checks the collected signatures against m.Permission offline. The
+// node remains the reference, see Client.GetSignWeight.
+func (m *MultisigTx) Check() (*SignatureCheck, error) {
+	if m.Permission == nil {
+		return nil, ErrUnknownPermission
+	}
+	return CheckSignatures(m.Transaction, m.Permission)
+}
+
+// ApprovedList is the response of /wallet/getapprovedlist.
+type ApprovedList struct {
+	Result struct {
+		Code    string `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"result"`
+	ApprovedList []string     `json:"approved_list,omitempty"`
+	Transaction  *Transaction `json:"transaction,omitempty"`
+}
+
+// SignWeight is the response of /wallet/getsignweight.
+type SignWeight struct {
+	Permission *Permission `json:"permission,omitempty"`
+	Result     struct {
+		Code    string `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"result"`
+	ApprovedList  []string     `json:"approved_list,omitempty"`
+	CurrentWeight int64        `json:"current_weight,omitempty"`
+	Transaction   *Transaction `json:"transaction,omitempty"`
+}
+
+// Enough reports whether the signatures reach the threshold of the
+// permission.
+func (w *SignWeight) Enough() bool {
+	return w.Result.Code == SignWeightEnough
+}
+
+// GetApprovedList Query the addresses that signed a transaction.
+func (c *Client) GetApprovedList(tx *Transaction) (*ApprovedList, error) {
+	var list ApprovedList
+	if err := c.post("/wallet/getapprovedlist", tx, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetSignWeight Query the weight of the signatures of a transaction and the
+// permission it is signed with.
+func (c *Client) GetSignWeight(tx *Transaction) (*SignWeight, error) {
+	var weight SignWeight
+	if err := c.post("/wallet/getsignweight", tx, &weight); err != nil {
+		return nil, err
+	}
+	return &weight, nil
+}
+
+// BroadcastMultisig Broadcast a multisig transaction once the node confirms
+// with getsignweight that its signatures reach the threshold. Otherwise a
+// *ThresholdNotReachedError is returned and nothing is broadcast.
+func (c *Client) BroadcastMultisig(m *MultisigTx) (*Transaction, error) {
+	if err := m.verify(); err != nil {
+		return nil, err
+	}
+
+	weight, err := c.GetSignWeight(m.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if !weight.Enough() {
+		e := &ThresholdNotReachedError{
+			TxID:    m.Transaction.TxId,
+			Weight:  weight.CurrentWeight,
+			Code:    weight.Result.Code,
+			Message: weight.Result.Message,
+		}
+		if weight.Permission != nil {
+			e.Threshold = weight.Permission.Threshold
+		}
+		return nil, e
+	}
+
+	return c.BroadcastTx(m.Transaction)
+}
@@ -0,0 +1,103 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Consistency selects the node answering a read: the full node, which
+// reflects the latest (possibly reverted) blocks, or the solidity node,
+// which only reflects irreversible blocks, about 1 minute behind.
+type Consistency int
+
+const (
+	// Latest reads from the full node (/wallet).
+	Latest Consistency = iota
+	// Solidified reads from the solidity node (/walletsolidity). Use it for
+	// balances and receipts that decisions such as withdrawals rely on.
+	Solidified
+)
+
+// readPath returns the path of the read endpoint name, e.g. "getaccount",
+// on the node selected by consistency.
+func readPath(name string, consistency Consistency) string {
+	if consistency == Solidified {
+		return "/walletsolidity/" + name
+	}
+	return "/wallet/" + name
+}
+
+// post sends params as JSON to path and decodes the response in out. Paths
+// under /walletsolidity go to the solidity node, the others to the full
+// node. A nil params sends an empty body.
+func (c *Client) post(path string, params interface{}, out interface{}) error {
+	return c.postContext(context.Background(), path, params, out)
+}
+
+// postContext is post with a context: the request, and its retries, are
+// abandoned once ctx is done.
+func (c *Client) postContext(ctx context.Context, path string, params interface{}, out interface{}) error {
+	var body []byte
+	if params != nil {
+		encodeData, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		body = encodeData
+	}
+
+	base := c.network
+	if strings.HasPrefix(path, "/walletsolidity/") {
+		base = c.solidityNode
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", base+path, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	resp, err := c.client.CallRetryable(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	return json.NewDecoder(resp).Decode(out)
+}
+
+// NodeError is the error message returned by the node instead of a result,
+// e.g. when the parameters of a transaction are refused.
+type NodeError struct {
+	Path    string
+	Message string
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// createTx posts params to path, an endpoint creating a transaction, and
+// returns the transaction verified with VerifyTx.
+func (c *Client) createTx(path string, params interface{}) (*Transaction, error) {
+	var resp struct {
+		Transaction
+		Error string `json:"Error"`
+	}
+	if err := c.post(path, params, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, &NodeError{Path: path, Message: resp.Error}
+	}
+
+	tx := resp.Transaction
+	if err := VerifyTx(&tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
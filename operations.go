@@ -0,0 +1,121 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// operationsLength is the length in bytes of a permission operations bitmask.
+const operationsLength = 32
+
+// ErrInvalidOperations is returned when an operations bitmask is not 32
+// bytes long.
+var ErrInvalidOperations = errors.New("operations must be a 32 bytes bitmask")
+
+// Permission types, as in Permission.Type.
+const (
+	PermissionOwner   = "Owner"
+	PermissionWitness = "Witness"
+	PermissionActive  = "Active"
+)
+
+// Operations is the bitmask of the contract types an active permission is
+// allowed to sign: bit t%8 of byte t/8 is set when contract type t is
+// allowed. It is hex encoded in JSON, and empty for owner and witness
+// permissions.
+type Operations []byte
+
+// NewOperations returns the Operations allowing types.
+func NewOperations(types ...ContractType) Operations {
+	o := make(Operations, operationsLength)
+	for _, t := range types {
+		o.Add(t)
+	}
+	return o
+}
+
+// ParseOperations decodes a hex encoded operations bitmask.
+func ParseOperations(s string) (Operations, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 && len(b) != operationsLength {
+		return nil, ErrInvalidOperations
+	}
+	return Operations(b), nil
+}
+
+// Has reports whether contract type t is allowed by o.
+func (o Operations) Has(t ContractType) bool {
+	if t < 0 || int(t)/8 >= len(o) {
+		return false
+	}
+	return o[t/8]&(1<<(uint(t)%8)) != 0
+}
+
+// Add allows contract type t. o must have been created by NewOperations or
+// ParseOperations.
+func (o Operations) Add(t ContractType) {
+	if t >= 0 && int(t)/8 < len(o) {
+		o[t/8] |= 1 << (uint(t) % 8)
+	}
+}
+
+// Remove disallows contract type t.
+func (o Operations) Remove(t ContractType) {
+	if t >= 0 && int(t)/8 < len(o) {
+		o[t/8] &^= 1 << (uint(t) % 8)
+	}
+}
+
+// Types returns the contract types allowed by o, in increasing order.
+func (o Operations) Types() []ContractType {
+	var types []ContractType
+	for i := 0; i < len(o)*8; i++ {
+		if o.Has(ContractType(i)) {
+			types = append(types, ContractType(i))
+		}
+	}
+	return types
+}
+
+// String returns the hex encoding of o.
+func (o Operations) String() string {
+	return hex.EncodeToString(o)
+}
+
+// MarshalJSON encodes o as a hex string.
+func (o Operations) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+// UnmarshalJSON decodes o from a hex string.
+func (o *Operations) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	ops, err := ParseOperations(s)
+	if err != nil {
+		return err
+	}
+	*o = ops
+	return nil
+}
+
+// Allows reports whether p may sign contracts of type t: the owner
+// permission allows every contract, active permissions the ones in their
+// operations, and the witness permission only produces blocks. The node
+// omits the type of owner permissions, being the protobuf default.
+func (p *Permission) Allows(t ContractType) bool {
+	switch p.Type {
+	case PermissionOwner, "":
+		return true
+	case PermissionWitness:
+		return false
+	}
+	return p.Operations.Has(t)
+}
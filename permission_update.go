@@ -0,0 +1,167 @@
+package tronhttpClient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// Limits enforced by the node on account permissions.
+const (
+	MaxPermissionKeys    = 5
+	MaxActivePermissions = 8
+)
+
+// ErrInvalidPermission is wrapped by the errors returned by
+// PermissionUpdate.Validate.
+var ErrInvalidPermission = errors.New("invalid permission")
+
+// NewOwnerPermission returns an owner permission with threshold and keys.
+func NewOwnerPermission(threshold int64, keys ...Key) Permission {
+	return Permission{
+		Type:           PermissionOwner,
+		PermissionName: "owner",
+		Threshold:      threshold,
+		Keys:           keys,
+	}
+}
+
+// NewWitnessPermission returns the witness permission of a super
+// representative, with the single key producing blocks.
+func NewWitnessPermission(key string) Permission {
+	return Permission{
+		Type:           PermissionWitness,
+		PermissionName: "witness",
+		Threshold:      1,
+		Keys:           []Key{{Address: key, Weight: 1}},
+	}
+}
+
+// NewActivePermission returns an active permission named name, allowing ops
+// when the weights of its signers reach threshold.
+func NewActivePermission(name string, threshold int64, ops Operations, keys ...Key) Permission {
+	return Permission{
+		Type:           PermissionActive,
+		PermissionName: name,
+		Threshold:      threshold,
+		Operations:     ops,
+		Keys:           keys,
+	}
+}
+
+// PermissionUpdate replaces all the permissions of an account with
+// /wallet/accountpermissionupdate. Witness must only be set for super
+// representatives. Every permission not present is removed, and at least
+// one active permission is required.
+type PermissionUpdate struct {
+	OwnerAddress string
+	Owner        Permission
+	Witness      *Permission
+	Actives      []Permission
+	Visible      bool
+}
+
+// Validate checks u with the rules the node applies: key counts, unique
+// and valid key addresses, positive weights and thresholds reachable by the
+// sum of the weights, and operations only on active permissions.
+func (u *PermissionUpdate) Validate() error {
+	if !address.IsValid(u.OwnerAddress) {
+		return fmt.Errorf("%w: invalid owner address %q", ErrInvalidPermission, u.OwnerAddress)
+	}
+
+	if err := validatePermission(&u.Owner, PermissionOwner); err != nil {
+		return err
+	}
+	if u.Witness != nil {
+		if err := validatePermission(u.Witness, PermissionWitness); err != nil {
+			return err
+		}
+		if len(u.Witness.Keys) != 1 {
+			return fmt.Errorf("%w: witness permission must have exactly one key", ErrInvalidPermission)
+		}
+	}
+
+	if len(u.Actives) == 0 || len(u.Actives) > MaxActivePermissions {
+		return fmt.Errorf("%w: between 1 and %d active permissions are required", ErrInvalidPermission, MaxActivePermissions)
+	}
+	for i := range u.Actives {
+		if err := validatePermission(&u.Actives[i], PermissionActive); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePermission(p *Permission, typ string) error {
+	name := p.PermissionName
+	if name == "" {
+		name = typ
+	}
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidPermission, name, fmt.Sprintf(format, args...))
+	}
+
+	// The owner type is the zero value of the enum, which the node omits.
+	if p.Type != typ && !(typ == PermissionOwner && p.Type == "") {
+		return invalid("type must be %s", typ)
+	}
+	if len(p.Keys) == 0 || len(p.Keys) > MaxPermissionKeys {
+		return invalid("between 1 and %d keys are required", MaxPermissionKeys)
+	}
+	if p.Threshold <= 0 {
+		return invalid("threshold must be positive")
+	}
+
+	if typ == PermissionActive {
+		if len(p.Operations) != operationsLength {
+			return invalid("operations are required")
+		}
+	} else if len(p.Operations) != 0 {
+		return invalid("only active permissions have operations")
+	}
+
+	var sum int64
+	seen := make(map[string]bool, len(p.Keys))
+	for _, k := range p.Keys {
+		addr, err := address.Decode(k.Address)
+		if err != nil {
+			return invalid("invalid key address %q", k.Address)
+		}
+		if seen[string(addr)] {
+			return invalid("duplicated key address %q", k.Address)
+		}
+		seen[string(addr)] = true
+
+		if k.Weight <= 0 {
+			return invalid("key %q weight must be positive", k.Address)
+		}
+		if sum += k.Weight; sum < 0 {
+			return invalid("weights overflow")
+		}
+	}
+	if sum < p.Threshold {
+		return invalid("sum of weights %d is lower than threshold %d", sum, p.Threshold)
+	}
+	return nil
+}
+
+// AccountPermissionUpdate Update the permissions of an account after
+// validating them locally. Note: the update burns a fee (100 TRX on mainnet).
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) AccountPermissionUpdate(u *PermissionUpdate) (*Transaction, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"owner_address": u.OwnerAddress,
+		"owner":         u.Owner,
+		"actives":       u.Actives,
+		"visible":       u.Visible,
+	}
+	if u.Witness != nil {
+		params["witness"] = u.Witness
+	}
+	return c.createTx("/wallet/accountpermissionupdate", params)
+}
@@ -0,0 +1,619 @@
+package tronhttpClient
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+var (
+	// ErrInvalidProtobuf is returned when protobuf encoded data is malformed.
+	ErrInvalidProtobuf = errors.New("invalid protobuf data")
+	// ErrSignedTx is returned when modifying the raw data of a signed
+	// transaction.
+	ErrSignedTx = errors.New("transaction is already signed")
+
+	// errNoValueType is wrapped by the errors of contracts whose parameter
+	// value has no registered type, and so can not be encoded.
+	errNoValueType = errors.New("no registered value type")
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoField is a single decoded protobuf field. Only one of varint and
+// bytes is set, depending on wire.
+type protoField struct {
+	num    int
+	wire   int
+	varint uint64
+	bytes  []byte
+}
+
+// decodeProto splits b in its top level fields, in wire order.
+func decodeProto(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, ErrInvalidProtobuf
+		}
+		b = b[n:]
+
+		f := protoField{num: int(key >> 3), wire: int(key & 7)}
+		if f.num == 0 {
+			return nil, ErrInvalidProtobuf
+		}
+
+		switch f.wire {
+		case wireVarint:
+			f.varint, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, ErrInvalidProtobuf
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return nil, ErrInvalidProtobuf
+			}
+			f.varint = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return nil, ErrInvalidProtobuf
+			}
+			f.varint = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return nil, ErrInvalidProtobuf
+			}
+			f.bytes = b[n : n+int(l)]
+			b = b[n+int(l):]
+		default:
+			return nil, ErrInvalidProtobuf
+		}
+
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// appendVarintField appends the field num with varint value v to b.
+func appendVarintField(b []byte, num int, v uint64) []byte {
+	b = appendVarint(b, uint64(num)<<3|wireVarint)
+	return appendVarint(b, v)
+}
+
+// appendBytesField appends the length delimited field num to b.
+func appendBytesField(b []byte, num int, v []byte) []byte {
+	b = appendVarint(b, uint64(num)<<3|wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// pbEnums are the protobuf enums used by contract values, keyed by the kind
+// of their pb tag.
+var pbEnums = map[string]map[string]uint64{
+	"resource": {
+		"BANDWIDTH":  0,
+		"ENERGY":     1,
+		"TRON_POWER": 2,
+	},
+	"account_type": {
+		"Normal":     0,
+		"AssetIssue": 1,
+		"Contract":   2,
+	},
+	"permission_type": {
+		PermissionOwner:   0,
+		PermissionWitness: 1,
+		PermissionActive:  2,
+	},
+	"abi_entry_type": {
+		"UnknownEntryType": 0,
+		"Constructor":      1,
+		"Function":         2,
+		"Event":            3,
+		"Fallback":         4,
+		"Receive":          5,
+		"Error":            6,
+	},
+	"state_mutability": {
+		"UnknownMutabilityType": 0,
+		"Pure":                  1,
+		"View":                  2,
+		"Nonpayable":            3,
+		"Payable":               4,
+	},
+}
+
+// pbField is a struct field with a pb:"num,kind" tag. Kinds are:
+//
+//	address  a hex or base58 address, 21 bytes on the wire
+//	bytes    a hex string, or a []byte
+//	name     a hex string, or plain text in visible transactions
+//	string   a plain text string
+//	varint   an integer or a bool
+//	message  a struct or a pointer to a struct, or a slice of structs for
+//	         repeated fields
+//
+// and the enums in pbEnums.
+type pbField struct {
+	index int
+	num   int
+	kind  string
+}
+
+func pbFields(t reflect.Type) ([]pbField, error) {
+	var fields []pbField
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("pb")
+		if tag == "" {
+			continue
+		}
+
+		parts := strings.SplitN(tag, ",", 2)
+		num, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid pb tag %q on %s.%s", tag, t.Name(), t.Field(i).Name)
+		}
+		fields = append(fields, pbField{index: i, num: num, kind: parts[1]})
+	}
+
+	// Fields are serialized in field number order, as the node does.
+	sort.Slice(fields, func(i, j int) bool { return fields[i].num < fields[j].num })
+	return fields, nil
+}
+
+// encodeMessage encodes the struct pointed by msg using its pb tags. Fields
+// holding their zero value are omitted, as proto3 does.
+func encodeMessage(msg interface{}, visible bool) ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(msg))
+	fields, err := pbFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		if fv.IsZero() {
+			continue
+		}
+
+		switch f.kind {
+		case "address":
+			addr, err := address.Decode(fv.String())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Type().Field(f.index).Name, err)
+			}
+			b = appendBytesField(b, f.num, addr)
+		case "bytes", "name":
+			if fv.Kind() == reflect.Slice {
+				b = appendBytesField(b, f.num, fv.Bytes())
+				continue
+			}
+			raw, err := hex.DecodeString(fv.String())
+			if f.kind == "name" && visible {
+				raw, err = []byte(fv.String()), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Type().Field(f.index).Name, err)
+			}
+			b = appendBytesField(b, f.num, raw)
+		case "string":
+			b = appendBytesField(b, f.num, []byte(fv.String()))
+		case "varint":
+			switch fv.Kind() {
+			case reflect.Bool:
+				b = appendVarintField(b, f.num, 1)
+			default:
+				b = appendVarintField(b, f.num, uint64(fv.Int()))
+			}
+		case "message":
+			if fv.Kind() == reflect.Ptr {
+				sub, err := encodeMessage(fv.Interface(), visible)
+				if err != nil {
+					return nil, err
+				}
+				b = appendBytesField(b, f.num, sub)
+				continue
+			}
+			if fv.Kind() != reflect.Slice {
+				sub, err := encodeMessage(fv.Addr().Interface(), visible)
+				if err != nil {
+					return nil, err
+				}
+				b = appendBytesField(b, f.num, sub)
+				continue
+			}
+			for i := 0; i < fv.Len(); i++ {
+				sub, err := encodeMessage(fv.Index(i).Addr().Interface(), visible)
+				if err != nil {
+					return nil, err
+				}
+				b = appendBytesField(b, f.num, sub)
+			}
+		default:
+			enum, ok := pbEnums[f.kind]
+			if !ok {
+				return nil, fmt.Errorf("unknown pb kind %q", f.kind)
+			}
+			n, ok := enum[fv.String()]
+			if !ok {
+				return nil, fmt.Errorf("invalid %s %q", f.kind, fv.String())
+			}
+			if n != 0 {
+				b = appendVarintField(b, f.num, n)
+			}
+		}
+	}
+	return b, nil
+}
+
+// decodeMessage decodes b in the struct pointed by msg using its pb tags.
+// Unknown fields are ignored.
+func decodeMessage(b []byte, msg interface{}, visible bool) error {
+	v := reflect.Indirect(reflect.ValueOf(msg))
+	fields, err := pbFields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	wire, err := decodeProto(b)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range wire {
+		for _, f := range fields {
+			if f.num != w.num {
+				continue
+			}
+
+			fv := v.Field(f.index)
+			switch f.kind {
+			case "address":
+				if visible {
+					fv.SetString(address.ToBase58(w.bytes))
+				} else {
+					fv.SetString(address.ToHex(w.bytes))
+				}
+			case "bytes":
+				if fv.Kind() == reflect.Slice {
+					fv.SetBytes(append([]byte{}, w.bytes...))
+				} else {
+					fv.SetString(hex.EncodeToString(w.bytes))
+				}
+			case "name":
+				if visible {
+					fv.SetString(string(w.bytes))
+				} else {
+					fv.SetString(hex.EncodeToString(w.bytes))
+				}
+			case "string":
+				fv.SetString(string(w.bytes))
+			case "varint":
+				switch fv.Kind() {
+				case reflect.Bool:
+					fv.SetBool(w.varint != 0)
+				default:
+					fv.SetInt(int64(w.varint))
+				}
+			case "message":
+				if fv.Kind() == reflect.Ptr {
+					fv.Set(reflect.New(fv.Type().Elem()))
+					if err := decodeMessage(w.bytes, fv.Interface(), visible); err != nil {
+						return err
+					}
+					continue
+				}
+				if fv.Kind() != reflect.Slice {
+					if err := decodeMessage(w.bytes, fv.Addr().Interface(), visible); err != nil {
+						return err
+					}
+					continue
+				}
+				elem := reflect.New(fv.Type().Elem())
+				if err := decodeMessage(w.bytes, elem.Interface(), visible); err != nil {
+					return err
+				}
+				fv.Set(reflect.Append(fv, elem.Elem()))
+			default:
+				for name, n := range pbEnums[f.kind] {
+					if n == w.varint {
+						fv.SetString(name)
+					}
+				}
+			}
+		}
+	}
+
+	// Enums are omitted on the wire when they have the default value, which
+	// the node omits as well in JSON, so there is nothing to fill in.
+	return nil
+}
+
+// ProtobufValue is the protobuf encoded value of a contract parameter of
+// a type without a registered *Value struct, as decoded by DecodeRawData.
+type ProtobufValue []byte
+
+// MarshalJSON encodes v as a hex string.
+func (v ProtobufValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(v))
+}
+
+// EncodeRawData returns the protobuf encoding of raw, the Transaction.raw
+// message whose SHA-256 is the transaction id. visible tells how addresses
+// and names are formatted in raw, as in Transaction.Visible.
+func EncodeRawData(raw *RawData, visible bool) ([]byte, error) {
+	var b []byte
+	bytesField := func(num int, name, s string) error {
+		if s == "" {
+			return nil
+		}
+		v, err := hex.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		b = appendBytesField(b, num, v)
+		return nil
+	}
+	varintField := func(num int, v int64) {
+		if v != 0 {
+			b = appendVarintField(b, num, uint64(v))
+		}
+	}
+
+	if err := bytesField(1, "ref_block_bytes", raw.RefBlockBytes); err != nil {
+		return nil, err
+	}
+	varintField(3, raw.RefBlockNum)
+	if err := bytesField(4, "ref_block_hash", raw.RefBlockHash); err != nil {
+		return nil, err
+	}
+	varintField(8, raw.Expiration)
+	if err := bytesField(10, "data", raw.Data); err != nil {
+		return nil, err
+	}
+	for i := range raw.Contract {
+		c, err := encodeContract(&raw.Contract[i], visible)
+		if err != nil {
+			return nil, fmt.Errorf("contract[%d]: %w", i, err)
+		}
+		b = appendBytesField(b, 11, c)
+	}
+	varintField(14, raw.Timestamp)
+	varintField(18, int64(raw.FeeLimit))
+
+	return b, nil
+}
+
+// encodeContractValue returns the protobuf encoding of the parameter value
+// of c.
+func encodeContractValue(c *Contract, visible bool) ([]byte, error) {
+	switch v := c.Parameter.Value.(type) {
+	case ProtobufValue:
+		return v, nil
+	case []byte:
+		return v, nil
+	case json.RawMessage, nil:
+		return nil, fmt.Errorf("can not encode %s: %w", c.Type, errNoValueType)
+	default:
+		return encodeMessage(v, visible)
+	}
+}
+
+func encodeContract(c *Contract, visible bool) ([]byte, error) {
+	if c.Type < 0 {
+		return nil, fmt.Errorf("can not encode the unknown contract type %s", c.Type)
+	}
+	value, err := encodeContractValue(c, visible)
+	if err != nil {
+		return nil, err
+	}
+
+	typeURL := c.Parameter.TypeURL
+	if typeURL == "" {
+		typeURL = c.Type.TypeURL()
+	}
+
+	var param []byte
+	param = appendBytesField(param, 1, []byte(typeURL))
+	param = appendBytesField(param, 2, value)
+
+	var b []byte
+	if c.Type != 0 {
+		b = appendVarintField(b, 1, uint64(c.Type))
+	}
+	b = appendBytesField(b, 2, param)
+	if c.PermissionID != 0 {
+		b = appendVarintField(b, 5, uint64(c.PermissionID))
+	}
+	return b, nil
+}
+
+// DecodeRawData decodes a protobuf encoded Transaction.raw message. visible
+// tells whether addresses and names are formatted in base58 and plain text
+// or in hex.
+func DecodeRawData(b []byte, visible bool) (*RawData, error) {
+	fields, err := decodeProto(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw RawData
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			raw.RefBlockBytes = hex.EncodeToString(f.bytes)
+		case 3:
+			raw.RefBlockNum = int64(f.varint)
+		case 4:
+			raw.RefBlockHash = hex.EncodeToString(f.bytes)
+		case 8:
+			raw.Expiration = int64(f.varint)
+		case 10:
+			raw.Data = hex.EncodeToString(f.bytes)
+		case 11:
+			c, err := decodeContractMessage(f.bytes, visible)
+			if err != nil {
+				return nil, err
+			}
+			raw.Contract = append(raw.Contract, *c)
+		case 14:
+			raw.Timestamp = int64(f.varint)
+		case 18:
+			raw.FeeLimit = Sun(f.varint)
+		}
+	}
+	return &raw, nil
+}
+
+func decodeContractMessage(b []byte, visible bool) (*Contract, error) {
+	c, err := decodeContract(b)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &Contract{
+		PermissionID: int(c.permissionID),
+		Parameter:    ContractParameter{TypeURL: c.typeURL, Value: ProtobufValue(c.value)},
+	}
+
+	fields, err := decodeProto(b)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if f.num == 1 {
+			contract.Type = ContractType(f.varint)
+		}
+	}
+
+	if newValue, ok := contractValues[contract.Type]; ok && c.typeURL == contract.Type.TypeURL() {
+		value := newValue()
+		if err := decodeMessage(c.value, value, visible); err != nil {
+			return nil, err
+		}
+		contract.Parameter.Value = value
+	}
+	return contract, nil
+}
+
+// EncodeTransaction returns the protobuf encoding of the Transaction message
+// of tx, as expected by BroadcastHex. The raw data is taken from
+// tx.RawDataHex when set, since it is what has been signed, otherwise it is
+// encoded from tx.RawData.
+func EncodeTransaction(tx *Transaction) ([]byte, error) {
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		if raw, err = EncodeRawData(&tx.RawData, tx.Visible); err != nil {
+			return nil, err
+		}
+	}
+
+	b := appendBytesField(nil, 1, raw)
+	for i, s := range tx.Signature {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		b = appendBytesField(b, 2, sig)
+	}
+	return b, nil
+}
+
+// DecodeTransaction decodes a protobuf encoded Transaction message and
+// computes its txID.
+func DecodeTransaction(b []byte, visible bool) (*Transaction, error) {
+	fields, err := decodeProto(b)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{Visible: visible}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			raw, err := DecodeRawData(f.bytes, visible)
+			if err != nil {
+				return nil, err
+			}
+			tx.RawData = *raw
+			tx.RawDataHex = hex.EncodeToString(f.bytes)
+		case 2:
+			tx.Signature = append(tx.Signature, hex.EncodeToString(f.bytes))
+		}
+	}
+
+	hash, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.TxId = hex.EncodeToString(hash)
+	return tx, nil
+}
+
+// TransactionToHex returns the hex protobuf encoding of tx, as expected by
+// BroadcastHex.
+func TransactionToHex(tx *Transaction) (string, error) {
+	b, err := EncodeTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// TransactionFromHex decodes a hex protobuf encoded transaction.
+func TransactionFromHex(s string, visible bool) (*Transaction, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTransaction(b, visible)
+}
+
+// UpdateRawDataHex encodes tx.RawData in tx.RawDataHex and sets tx.TxId
+// accordingly. Existing signatures are no longer valid for the new raw data,
+// so it fails on signed transactions.
+func (tx *Transaction) UpdateRawDataHex() error {
+	if len(tx.Signature) > 0 {
+		return ErrSignedTx
+	}
+
+	raw, err := EncodeRawData(&tx.RawData, tx.Visible)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(raw)
+	tx.RawDataHex = hex.EncodeToString(raw)
+	tx.TxId = hex.EncodeToString(hash[:])
+	return nil
+}
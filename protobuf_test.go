@@ -0,0 +1,329 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// wireField is a field of a protobuf message built by message.
+type wireField struct {
+	num int
+	v   interface{}
+}
+
+func field(num int, v interface{}) wireField {
+	return wireField{num, v}
+}
+
+// message encodes fields, in order, without the codec under test: ints are
+// varints and strings and byte slices are length delimited.
+func message(fields ...wireField) []byte {
+	var b []byte
+	for _, f := range fields {
+		switch v := f.v.(type) {
+		case int:
+			b = appendUvarint(b, uint64(f.num)<<3)
+			b = appendUvarint(b, uint64(v))
+		case string:
+			b = appendUvarint(b, uint64(f.num)<<3|2)
+			b = appendUvarint(b, uint64(len(v)))
+			b = append(b, v...)
+		case []byte:
+			b = appendUvarint(b, uint64(f.num)<<3|2)
+			b = appendUvarint(b, uint64(len(v)))
+			b = append(b, v...)
+		default:
+			panic(fmt.Sprintf("field %d: unsupported %T", f.num, v))
+		}
+	}
+	return b
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], x)]...)
+}
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// contract is a Transaction.Contract of type typ, its parameter a
+// google.protobuf.Any of the value.
+func contract(typ int, name string, value []byte, extra ...wireField) []byte {
+	fields := []wireField{
+		field(1, typ),
+		field(2, message(field(1, "type.googleapis.com/protocol."+name), field(2, value))),
+	}
+	return message(append(fields, extra...)...)
+}
+
+const (
+	ownerHex    = "41c8599111f29c1e1e061265b4af93ea1f274ad78a" // TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH
+	receiverHex = "4141d16df1f417a16410d0a1ee4d360d3f2319ad67" // TFyDowe63AUWrfB3xpJdaYXvxWjqH6RHHL
+	usdtHex     = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c" // TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t
+)
+
+// rawDataVectors are the signed raw data of transactions, written field by
+// field after the TRON protocol definitions (core/Tron.proto and
+// core/contract/*.proto), along with their raw_data in the JSON of the node.
+var rawDataVectors = []struct {
+	name    string
+	visible bool
+	raw     []byte
+	json    string
+}{
+	{
+		name: "transfer",
+		raw: message(
+			field(1, unhex("e0a3")),
+			field(4, unhex("0102030405060708")),
+			field(8, 1600000000000),
+			field(11, contract(1, "TransferContract", message(
+				field(1, unhex(ownerHex)),
+				field(2, unhex(receiverHex)),
+				field(3, 1000000),
+			))),
+			field(14, 1599999940000),
+		),
+		json: `{"ref_block_bytes":"e0a3","ref_block_hash":"0102030405060708","expiration":1600000000000,"contract":[{"type":"TransferContract","parameter":{"type_url":"type.googleapis.com/protocol.TransferContract","value":{"owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","to_address":"4141d16df1f417a16410d0a1ee4d360d3f2319ad67","amount":1000000}}}],"timestamp":1599999940000}`,
+	},
+	{
+		name:    "transfer with memo, permission and fee limit",
+		visible: true,
+		raw: message(
+			field(1, unhex("03e8")),
+			field(4, unhex("aabbccddeeff0011")),
+			field(8, 1600000060000),
+			field(10, "deposit-42"),
+			field(11, contract(1, "TransferContract", message(
+				field(1, unhex(ownerHex)),
+				field(2, unhex(receiverHex)),
+				field(3, 25),
+			), field(5, 2))),
+			field(14, 1600000000000),
+			field(18, 1000000),
+		),
+		json: `{"ref_block_bytes":"03e8","ref_block_hash":"aabbccddeeff0011","expiration":1600000060000,"data":"6465706f7369742d3432","contract":[{"type":"TransferContract","parameter":{"type_url":"type.googleapis.com/protocol.TransferContract","value":{"owner_address":"TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH","to_address":"TFyDowe63AUWrfB3xpJdaYXvxWjqH6RHHL","amount":25}},"Permission_id":2}],"timestamp":1600000000000,"fee_limit":1000000}`,
+	},
+	{
+		name: "transfer asset",
+		raw: message(
+			field(1, unhex("5c1d")),
+			field(4, unhex("8f3e2a1b00c4d5e6")),
+			field(8, 1681000057000),
+			field(11, contract(2, "TransferAssetContract", message(
+				field(1, "1002000"),
+				field(2, unhex(ownerHex)),
+				field(3, unhex(receiverHex)),
+				field(4, 42),
+			))),
+			field(14, 1681000000000),
+		),
+		json: `{"ref_block_bytes":"5c1d","ref_block_hash":"8f3e2a1b00c4d5e6","expiration":1681000057000,"contract":[{"type":"TransferAssetContract","parameter":{"type_url":"type.googleapis.com/protocol.TransferAssetContract","value":{"asset_name":"31303032303030","owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","to_address":"4141d16df1f417a16410d0a1ee4d360d3f2319ad67","amount":42}}}],"timestamp":1681000000000}`,
+	},
+	{
+		name: "trigger smart contract",
+		raw: message(
+			field(1, unhex("e0a3")),
+			field(4, unhex("0102030405060708")),
+			field(8, 1600000000000),
+			field(11, contract(31, "TriggerSmartContract", message(
+				field(1, unhex(ownerHex)),
+				field(2, unhex(usdtHex)),
+				// transfer(TFyDowe63AUWrfB3xpJdaYXvxWjqH6RHHL, 2500000)
+				field(4, unhex("a9059cbb00000000000000000000000041d16df1f417a16410d0a1ee4d360d3f2319ad6700000000000000000000000000000000000000000000000000000000002625a0")),
+			))),
+			field(14, 1599999940000),
+			field(18, 100000000),
+		),
+		json: `{"ref_block_bytes":"e0a3","ref_block_hash":"0102030405060708","expiration":1600000000000,"contract":[{"type":"TriggerSmartContract","parameter":{"type_url":"type.googleapis.com/protocol.TriggerSmartContract","value":{"owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","contract_address":"41a614f803b6fd780986a42c78ec9c7f77e6ded13c","data":"a9059cbb00000000000000000000000041d16df1f417a16410d0a1ee4d360d3f2319ad6700000000000000000000000000000000000000000000000000000000002625a0"}}}],"timestamp":1599999940000,"fee_limit":100000000}`,
+	},
+	{
+		name: "freeze balance v2",
+		raw: message(
+			field(1, unhex("5c1e")),
+			field(4, unhex("8f3e2a1b00c4d5e6")),
+			field(8, 1681000060000),
+			field(11, contract(54, "FreezeBalanceV2Contract", message(
+				field(1, unhex(ownerHex)),
+				field(2, 10000000),
+				field(3, 1), // ENERGY
+			))),
+			field(14, 1681000003000),
+		),
+		json: `{"ref_block_bytes":"5c1e","ref_block_hash":"8f3e2a1b00c4d5e6","expiration":1681000060000,"contract":[{"type":"FreezeBalanceV2Contract","parameter":{"type_url":"type.googleapis.com/protocol.FreezeBalanceV2Contract","value":{"owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","frozen_balance":10000000,"resource":"ENERGY"}}}],"timestamp":1681000003000}`,
+	},
+	{
+		name:    "delegate resource",
+		visible: true,
+		raw: message(
+			field(1, unhex("e0a3")),
+			field(4, unhex("0102030405060708")),
+			field(8, 1600000000000),
+			field(11, contract(57, "DelegateResourceContract", message(
+				field(1, unhex(ownerHex)),
+				field(2, 1), // ENERGY
+				field(3, 5000000),
+				field(4, unhex(receiverHex)),
+				field(5, 1),
+			))),
+			field(14, 1599999940000),
+		),
+		json: `{"ref_block_bytes":"e0a3","ref_block_hash":"0102030405060708","expiration":1600000000000,"contract":[{"type":"DelegateResourceContract","parameter":{"type_url":"type.googleapis.com/protocol.DelegateResourceContract","value":{"owner_address":"TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH","resource":"ENERGY","balance":5000000,"receiver_address":"TFyDowe63AUWrfB3xpJdaYXvxWjqH6RHHL","lock":true}}}],"timestamp":1599999940000}`,
+	},
+	{
+		name: "account permission update",
+		raw: message(
+			field(1, unhex("5c20")),
+			field(4, unhex("8f3e2a1b00c4d5e6")),
+			field(8, 1681000066000),
+			field(11, contract(46, "AccountPermissionUpdateContract", message(
+				field(1, unhex(ownerHex)),
+				// Owner permission: type Owner and id 0 are the defaults.
+				field(2, message(
+					field(3, "owner"),
+					field(4, 1),
+					field(7, message(field(1, unhex(ownerHex)), field(2, 1))),
+				)),
+				field(4, message(
+					field(1, 2), // Active
+					field(2, 2),
+					field(3, "active"),
+					field(4, 2),
+					field(6, unhex("7fff1fc0033e0000000000000000000000000000000000000000000000000000")),
+					field(7, message(field(1, unhex(ownerHex)), field(2, 1))),
+					field(7, message(field(1, unhex(receiverHex)), field(2, 1))),
+				)),
+			))),
+			field(14, 1681000006000),
+		),
+		json: `{"ref_block_bytes":"5c20","ref_block_hash":"8f3e2a1b00c4d5e6","expiration":1681000066000,"contract":[{"type":"AccountPermissionUpdateContract","parameter":{"type_url":"type.googleapis.com/protocol.AccountPermissionUpdateContract","value":{"owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","owner":{"permission_name":"owner","threshold":1,"keys":[{"address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","weight":1}]},"actives":[{"type":"Active","id":2,"permission_name":"active","threshold":2,"operations":"7fff1fc0033e0000000000000000000000000000000000000000000000000000","keys":[{"address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","weight":1},{"address":"4141d16df1f417a16410d0a1ee4d360d3f2319ad67","weight":1}]}]}}}],"timestamp":1681000006000}`,
+	},
+}
+
+// jsonEqual reports whether a and b are the same JSON value, regardless of
+// the order of the keys.
+func jsonEqual(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestDecodeRawData(t *testing.T) {
+	for _, v := range rawDataVectors {
+		raw, err := DecodeRawData(v.raw, v.visible)
+		if err != nil {
+			t.Errorf("%s: DecodeRawData: %v", v.name, err)
+			continue
+		}
+		got, _ := json.Marshal(raw)
+		if !jsonEqual(got, []byte(v.json)) {
+			t.Errorf("%s: DecodeRawData =\n%s\nwant\n%s", v.name, got, v.json)
+		}
+	}
+}
+
+func TestEncodeRawData(t *testing.T) {
+	for _, v := range rawDataVectors {
+		var raw RawData
+		if err := json.Unmarshal([]byte(v.json), &raw); err != nil {
+			t.Fatalf("%s: %v", v.name, err)
+		}
+		b, err := EncodeRawData(&raw, v.visible)
+		if err != nil {
+			t.Errorf("%s: EncodeRawData: %v", v.name, err)
+			continue
+		}
+		if !bytes.Equal(b, v.raw) {
+			t.Errorf("%s: EncodeRawData =\n%x\nwant\n%x", v.name, b, v.raw)
+		}
+	}
+}
+
+func TestVerifyTxVectors(t *testing.T) {
+	for _, v := range rawDataVectors {
+		id := sha256.Sum256(v.raw)
+		tx := &Transaction{TxId: hex.EncodeToString(id[:]), RawDataHex: hex.EncodeToString(v.raw), Visible: v.visible}
+		if err := json.Unmarshal([]byte(v.json), &tx.RawData); err != nil {
+			t.Fatalf("%s: %v", v.name, err)
+		}
+		if err := VerifyTx(tx); err != nil {
+			t.Errorf("%s: VerifyTx: %v", v.name, err)
+		}
+
+		// The same raw data with a later expiration than the signed one.
+		tx.RawData.Expiration++
+		var integrity *TxIntegrityError
+		if err := VerifyTx(tx); !errors.As(err, &integrity) {
+			t.Errorf("%s: VerifyTx of tampered raw data = %v, want a *TxIntegrityError", v.name, err)
+		}
+	}
+}
+
+func TestTransactionHexRoundTrip(t *testing.T) {
+	v := rawDataVectors[0]
+	tx := &Transaction{}
+	if err := json.Unmarshal([]byte(v.json), &tx.RawData); err != nil {
+		t.Fatal(err)
+	}
+	// Expired transactions are refused by SignTx.
+	tx.RawData.Expiration = 4102444800000
+	if err := tx.UpdateRawDataHex(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SignTx(tx, "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28"); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := TransactionToHex(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := TransactionFromHex(s, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.TxId != tx.TxId || decoded.RawDataHex != tx.RawDataHex {
+		t.Errorf("TransactionFromHex = %s %s, want %s %s", decoded.TxId, decoded.RawDataHex, tx.TxId, tx.RawDataHex)
+	}
+	if len(decoded.Signature) != 1 || decoded.Signature[0] != tx.Signature[0] {
+		t.Errorf("signatures = %v, want %v", decoded.Signature, tx.Signature)
+	}
+
+	signers, err := RecoverSigners(decoded)
+	if err != nil || len(signers) != 1 || signers[0] != "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH" {
+		t.Errorf("RecoverSigners = %v, %v", signers, err)
+	}
+}
+
+func TestDecodeRawDataErrors(t *testing.T) {
+	valid := rawDataVectors[0].raw
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"truncated", valid[:len(valid)-3]},
+		{"length past the end", []byte{0x0a, 0x05, 0x01}},
+		{"unterminated varint", []byte{0x40, 0x80}},
+		{"trailing garbage", append(append([]byte(nil), valid...), bytes.Repeat([]byte{0xff}, 2)...)},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeRawData(tt.b, false); err == nil {
+			t.Errorf("%s: DecodeRawData succeeded", tt.name)
+		}
+	}
+}
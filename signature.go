@@ -0,0 +1,270 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/decred/dcrd/dcrec/secp256k1/v3/ecdsa"
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature is not a valid 65 bytes
+	// r || s || v recoverable secp256k1 signature.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnknownPermission is returned when an account has no permission with
+	// the requested id.
+	ErrUnknownPermission = errors.New("unknown permission id")
+)
+
+// Permission ids of an account: owner is always 0, witness 1 and the
+// active permissions 2 and up.
+const (
+	OwnerPermissionID   = 0
+	WitnessPermissionID = 1
+)
+
+// txHash returns the hash signed by the signers of tx, the SHA-256 of the
+// protobuf encoded raw data.
+func txHash(tx *Transaction) ([]byte, error) {
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+// signHash signs hash with priv and returns the 65 bytes r || s || v
+// signature, with v being the recovery id (0 or 1).
+func signHash(priv *secp256k1.PrivateKey, hash []byte) []byte {
+	compact := ecdsa.SignCompact(priv, hash, false)
+	return append(compact[1:], compact[0]-27)
+}
+
+// recoverHash returns the 21 bytes address of the signer of hash.
+// v can be either the recovery id or the recovery id plus 27.
+func recoverHash(sig, hash []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	compact := append([]byte{27 + v}, sig[:64]...)
+	pub, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return addressFromPublicKey(pub), nil
+}
+
+// SignTx signs tx locally with the hex encoded privKey and appends the
+// signature to tx.Signature. Unlike GetTxSign the private key never leaves
+// the process. The transaction is verified with VerifyTx before signing,
+// and expired transactions are refused with a *TxExpiredError.
+func SignTx(tx *Transaction, privKey string) (*Transaction, error) {
+	return SignTxWithOptions(tx, privKey, nil)
+}
+
+// SignOptions are the options of SignTxWithOptions and GetTxSignWithOptions.
+type SignOptions struct {
+	// AllowUnverifiable signs transactions for which VerifyTx returns
+	// ErrUnverifiable: their txID matches raw_data_hex, but the contracts
+	// without a registered value struct are signed as the node built them,
+	// unchecked.
+	AllowUnverifiable bool
+}
+
+// verifyForSigning verifies tx with VerifyTx, according to opts.
+func verifyForSigning(tx *Transaction, opts *SignOptions) error {
+	err := VerifyTx(tx)
+	if opts != nil && opts.AllowUnverifiable && errors.Is(err, ErrUnverifiable) {
+		return nil
+	}
+	return err
+}
+
+// SignTxWithOptions is SignTx, with the transactions VerifyTx can not
+// fully verify signed when opts allows it.
+func SignTxWithOptions(tx *Transaction, privKey string, opts *SignOptions) (*Transaction, error) {
+	priv, err := parsePrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyForSigning(tx, opts); err != nil {
+		return nil, err
+	}
+	if err := checkExpiration(tx); err != nil {
+		return nil, err
+	}
+
+	hash, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Signature = append(tx.Signature, hex.EncodeToString(signHash(priv, hash)))
+	return tx, nil
+}
+
+// RecoverSigners returns the base58 addresses of the signers of tx, in the
+// same order as tx.Signature.
+func RecoverSigners(tx *Transaction) ([]string, error) {
+	hash, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]string, 0, len(tx.Signature))
+	for i, s := range tx.Signature {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+
+		addr, err := recoverHash(sig, hash)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		signers = append(signers, address.ToBase58(addr))
+	}
+
+	return signers, nil
+}
+
+// Permission returns the permission of a with the given id.
+func (a *Account) Permission(id int) (*Permission, error) {
+	if id == OwnerPermissionID {
+		return &a.OwnerPermission, nil
+	}
+	if id == WitnessPermissionID && a.WitnessPermission != nil {
+		return a.WitnessPermission, nil
+	}
+	for i := range a.ActivePermission {
+		if a.ActivePermission[i].Id == id {
+			return &a.ActivePermission[i], nil
+		}
+	}
+	return nil, ErrUnknownPermission
+}
+
+// SignatureCheck is the result of checking the signers of a transaction
+// against a permission.
+type SignatureCheck struct {
+	// Signers are the base58 addresses recovered from the signatures.
+	Signers []string
+	// Unauthorized are the signers which are not keys of the permission.
+	Unauthorized []string
+	// Duplicated are the signers that signed more than once, their weight
+	// is only counted once.
+	Duplicated []string
+	// Weight is the sum of the weights of the authorized signers.
+	Weight int64
+	// Threshold is the threshold of the permission.
+	Threshold int64
+}
+
+// Satisfied reports whether the signers reach the threshold of the
+// permission and no signature is unauthorized or duplicated, the same rules
+// the node applies before accepting the transaction.
+func (c *SignatureCheck) Satisfied() bool {
+	return c.Weight >= c.Threshold && len(c.Unauthorized) == 0 && len(c.Duplicated) == 0
+}
+
+// CheckSignatures recovers the signers of tx and checks them against the
+// keys and weights of perm.
+func CheckSignatures(tx *Transaction, perm *Permission) (*SignatureCheck, error) {
+	signers, err := RecoverSigners(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, len(perm.Keys))
+	for i, k := range perm.Keys {
+		// Keys are in hex or base58 depending on the visible flag of the query.
+		if keys[i], err = address.Decode(k.Address); err != nil {
+			return nil, fmt.Errorf("permission key %q: %w", k.Address, err)
+		}
+	}
+
+	check := &SignatureCheck{Signers: signers, Threshold: perm.Threshold}
+	seen := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		if seen[s] {
+			check.Duplicated = append(check.Duplicated, s)
+			continue
+		}
+		seen[s] = true
+
+		addr, _ := address.Decode(s)
+		authorized := false
+		for i, k := range keys {
+			if bytes.Equal(k, addr) {
+				check.Weight += perm.Keys[i].Weight
+				authorized = true
+				break
+			}
+		}
+		if !authorized {
+			check.Unauthorized = append(check.Unauthorized, s)
+		}
+	}
+
+	return check, nil
+}
+
+// CheckAccountSignatures checks the signers of tx against the permission
+// permissionID of account, as returned by GetAccount.
+func CheckAccountSignatures(tx *Transaction, account *Account, permissionID int) (*SignatureCheck, error) {
+	perm, err := account.Permission(permissionID)
+	if err != nil {
+		return nil, err
+	}
+	return CheckSignatures(tx, perm)
+}
+
+// Signer signs transactions for an address, e.g. a local private key.
+type Signer interface {
+	// Address returns the base58 address of the signer.
+	Address() string
+	// Sign appends the signature of the signer to tx.
+	Sign(tx *Transaction) error
+}
+
+// KeySigner is a Signer holding a private key in memory.
+type KeySigner struct {
+	privKey string
+	address string
+}
+
+// NewKeySigner returns a Signer for the hex encoded privKey.
+func NewKeySigner(privKey string) (*KeySigner, error) {
+	addr, err := AddressFromPrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{privKey: privKey, address: addr.Address}, nil
+}
+
+// Address returns the base58 address of the key.
+func (s *KeySigner) Address() string {
+	return s.address
+}
+
+// Sign signs tx with SignTx.
+func (s *KeySigner) Sign(tx *Transaction) error {
+	_, err := SignTx(tx, s.privKey)
+	return err
+}
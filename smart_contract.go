@@ -0,0 +1,335 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/stdevHsequeda/TRONHttpClient/abi"
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// ErrContractNotFound is returned when there is no smart contract at the
+// requested address.
+var ErrContractNotFound = errors.New("contract not found")
+
+// ErrNotDeployment is returned by ContractAddress for transactions that do
+// not deploy a smart contract.
+var ErrNotDeployment = errors.New("transaction does not deploy a contract")
+
+// ErrInvalidResourcePercent is returned when the percentage of the energy
+// paid by the caller is not between 0 and 100.
+var ErrInvalidResourcePercent = errors.New("consume user resource percent must be between 0 and 100")
+
+// SmartContract is a deployed smart contract, and the new_contract of
+// CreateSmartContract. Bytecode, CodeHash and TrxHash are hex encoded.
+type SmartContract struct {
+	OriginAddress   string            `json:"origin_address,omitempty" pb:"1,address"`
+	ContractAddress string            `json:"contract_address,omitempty" pb:"2,address"`
+	ABI             *SmartContractABI `json:"abi,omitempty" pb:"3,message"`
+	Bytecode        string            `json:"bytecode,omitempty" pb:"4,bytes"`
+	CallValue       Sun               `json:"call_value,omitempty" pb:"5,varint"`
+	// ConsumeUserResourcePercent is the percentage of the energy of calls
+	// paid by the caller, the rest being paid by the origin address.
+	ConsumeUserResourcePercent int64  `json:"consume_user_resource_percent,omitempty" pb:"6,varint"`
+	Name                       string `json:"name,omitempty" pb:"7,string"`
+	// OriginEnergyLimit is the most energy the origin address pays per call.
+	OriginEnergyLimit int64  `json:"origin_energy_limit,omitempty" pb:"8,varint"`
+	CodeHash          string `json:"code_hash,omitempty" pb:"9,bytes"`
+	TrxHash           string `json:"trx_hash,omitempty" pb:"10,bytes"`
+	Version           int32  `json:"version,omitempty" pb:"11,varint"`
+}
+
+// SmartContractABI is the ABI of a smart contract as stored by the node.
+// See ParseABI to encode and decode calls.
+type SmartContractABI struct {
+	Entrys []ABIEntry `json:"entrys,omitempty" pb:"1,message"`
+}
+
+// ABIEntry is a function, event, constructor, fallback or receive function
+// of a SmartContractABI.
+type ABIEntry struct {
+	Anonymous       bool       `json:"anonymous,omitempty" pb:"1,varint"`
+	Constant        bool       `json:"constant,omitempty" pb:"2,varint"`
+	Name            string     `json:"name,omitempty" pb:"3,string"`
+	Inputs          []ABIParam `json:"inputs,omitempty" pb:"4,message"`
+	Outputs         []ABIParam `json:"outputs,omitempty" pb:"5,message"`
+	Type            string     `json:"type,omitempty" pb:"6,abi_entry_type"`
+	Payable         bool       `json:"payable,omitempty" pb:"7,varint"`
+	StateMutability string     `json:"stateMutability,omitempty" pb:"8,state_mutability"`
+}
+
+// ABIParam is an input or output of an ABIEntry.
+type ABIParam struct {
+	Indexed bool   `json:"indexed,omitempty" pb:"1,varint"`
+	Name    string `json:"name,omitempty" pb:"2,string"`
+	Type    string `json:"type,omitempty" pb:"3,string"`
+}
+
+// ParseABI returns the ABI of the contract, to encode its calls and decode
+// their outputs and events.
+func (s *SmartContract) ParseABI() (*abi.ABI, error) {
+	if s.ABI == nil {
+		return &abi.ABI{}, nil
+	}
+	b, err := json.Marshal(s.ABI)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Parse(b)
+}
+
+// ContractState is the energy state of a smart contract.
+type ContractState struct {
+	EnergyUsage  int64 `json:"energy_usage,omitempty"`
+	EnergyFactor int64 `json:"energy_factor,omitempty"`
+	UpdateCycle  int64 `json:"update_cycle,omitempty"`
+}
+
+// ContractInfo is a smart contract with its runtime code and energy state.
+// RuntimeCode is hex encoded.
+type ContractInfo struct {
+	SmartContract SmartContract `json:"smart_contract"`
+	RuntimeCode   string        `json:"runtimecode,omitempty"`
+	ContractState ContractState `json:"contract_state"`
+}
+
+// CreateSmartContractValue is the parameter of CreateSmartContract.
+type CreateSmartContractValue struct {
+	OwnerAddress   string         `json:"owner_address,omitempty" pb:"1,address"`
+	NewContract    *SmartContract `json:"new_contract,omitempty" pb:"2,message"`
+	CallTokenValue int64          `json:"call_token_value,omitempty" pb:"3,varint"`
+	TokenID        int64          `json:"token_id,omitempty" pb:"4,varint"`
+}
+
+// UpdateSettingContractValue is the parameter of UpdateSettingContract.
+type UpdateSettingContractValue struct {
+	OwnerAddress               string `json:"owner_address,omitempty" pb:"1,address"`
+	ContractAddress            string `json:"contract_address,omitempty" pb:"2,address"`
+	ConsumeUserResourcePercent int64  `json:"consume_user_resource_percent,omitempty" pb:"3,varint"`
+}
+
+// UpdateEnergyLimitContractValue is the parameter of
+// UpdateEnergyLimitContract.
+type UpdateEnergyLimitContractValue struct {
+	OwnerAddress      string `json:"owner_address,omitempty" pb:"1,address"`
+	ContractAddress   string `json:"contract_address,omitempty" pb:"2,address"`
+	OriginEnergyLimit int64  `json:"origin_energy_limit,omitempty" pb:"3,varint"`
+}
+
+// ClearABIContractValue is the parameter of ClearABIContract.
+type ClearABIContractValue struct {
+	OwnerAddress    string `json:"owner_address,omitempty" pb:"1,address"`
+	ContractAddress string `json:"contract_address,omitempty" pb:"2,address"`
+}
+
+// ContractDeployment is the deployment of a smart contract.
+type ContractDeployment struct {
+	OwnerAddress string
+	Name         string
+	// Bytecode is the hex encoded creation code of the contract.
+	Bytecode string
+	// ABI is the JSON ABI of the contract, either the standard array of
+	// entries or {"entrys": [...]}.
+	ABI string
+	// ConstructorArgs are the arguments of the constructor, see abi.Encode.
+	ConstructorArgs []interface{}
+	// CallValue is the TRX sent to the constructor.
+	CallValue Sun
+	// CallTokenValue is the amount of the TRC10 asset TokenID sent to the
+	// constructor.
+	CallTokenValue int64
+	TokenID        int64
+	// ConsumeUserResourcePercent is the percentage, from 0 to 100, of the
+	// energy of calls paid by the caller, the rest being paid by the owner.
+	ConsumeUserResourcePercent int64
+	// OriginEnergyLimit is the most energy the owner pays per call.
+	OriginEnergyLimit int64
+	// FeeLimit is the most TRX the deployment may burn for energy,
+	// DefaultFeeLimit when zero.
+	FeeLimit Sun
+	// PermissionID is the permission of the owner signing the transaction.
+	PermissionID int
+	Visible      bool
+}
+
+// abiEntries returns the ABI JSON s as the array of entries expected by the
+// node.
+func abiEntries(s string) (json.RawMessage, error) {
+	raw := bytes.TrimSpace([]byte(s))
+	if len(raw) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	if raw[0] != '{' {
+		return raw, nil
+	}
+	var wrapped struct {
+		Entrys json.RawMessage `json:"entrys"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err != nil {
+		return nil, err
+	}
+	if wrapped.Entrys == nil {
+		return json.RawMessage("[]"), nil
+	}
+	return wrapped.Entrys, nil
+}
+
+// DeployContract Create the deployment of a smart contract. The address of
+// the contract, computed locally with ContractAddress, is returned along
+// with the transaction; it stays valid as long as the raw data of the
+// transaction is not changed.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) DeployContract(d *ContractDeployment) (*Transaction, string, error) {
+	if d.ConsumeUserResourcePercent < 0 || d.ConsumeUserResourcePercent > 100 {
+		return nil, "", ErrInvalidResourcePercent
+	}
+	entries, err := abiEntries(d.ABI)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var param []byte
+	if len(d.ConstructorArgs) > 0 {
+		contractABI, err := abi.Parse(entries)
+		if err != nil {
+			return nil, "", err
+		}
+		if contractABI.Constructor == nil {
+			return nil, "", abi.ErrNotFound
+		}
+		if param, err = contractABI.Constructor.Pack(d.ConstructorArgs...); err != nil {
+			return nil, "", err
+		}
+	}
+
+	feeLimit := d.FeeLimit
+	if feeLimit == 0 {
+		feeLimit = DefaultFeeLimit
+	}
+	params := map[string]interface{}{
+		"owner_address":                 d.OwnerAddress,
+		"name":                          d.Name,
+		"abi":                           string(entries),
+		"bytecode":                      d.Bytecode,
+		"parameter":                     hex.EncodeToString(param),
+		"call_value":                    d.CallValue,
+		"consume_user_resource_percent": d.ConsumeUserResourcePercent,
+		"origin_energy_limit":           d.OriginEnergyLimit,
+		"fee_limit":                     feeLimit,
+		"visible":                       d.Visible,
+	}
+	if d.CallTokenValue != 0 {
+		params["call_token_value"] = d.CallTokenValue
+		params["token_id"] = d.TokenID
+	}
+	if d.PermissionID != 0 {
+		params["Permission_id"] = d.PermissionID
+	}
+
+	tx, err := c.createTx("/wallet/deploycontract", params)
+	if err != nil {
+		return nil, "", err
+	}
+	addr, err := ContractAddress(tx)
+	if err != nil {
+		return nil, "", err
+	}
+	return tx, addr, nil
+}
+
+// ContractAddress returns the base58 address of the contract deployed by
+// tx: 0x41 followed by the last 20 bytes of the Keccak-256 of the txID and
+// the owner address, as computed by the node.
+func ContractAddress(tx *Transaction) (string, error) {
+	if len(tx.RawData.Contract) == 0 || tx.RawData.Contract[0].Type != CreateSmartContract {
+		return "", ErrNotDeployment
+	}
+	value, ok := tx.RawData.Contract[0].Parameter.Value.(*CreateSmartContractValue)
+	if !ok {
+		return "", ErrNotDeployment
+	}
+	owner, err := address.Decode(value.OwnerAddress)
+	if err != nil {
+		return "", err
+	}
+	txID, err := hex.DecodeString(tx.TxId)
+	if err != nil {
+		return "", err
+	}
+	hash := address.Keccak256(txID, owner)
+	return address.ToBase58(append([]byte{address.Prefix}, hash[12:]...)), nil
+}
+
+// GetContract Query a smart contract by its address.
+func (c *Client) GetContract(contractAddr string, visible bool) (*SmartContract, error) {
+	var contract SmartContract
+	err := c.post("/wallet/getcontract", map[string]interface{}{
+		"value":   contractAddr,
+		"visible": visible,
+	}, &contract)
+	if err != nil {
+		return nil, err
+	}
+	if contract.ContractAddress == "" && contract.Bytecode == "" {
+		return nil, ErrContractNotFound
+	}
+	return &contract, nil
+}
+
+// GetContractInfo Query a smart contract by its address, with its runtime
+// code and energy state.
+func (c *Client) GetContractInfo(contractAddr string, visible bool) (*ContractInfo, error) {
+	var info ContractInfo
+	err := c.post("/wallet/getcontractinfo", map[string]interface{}{
+		"value":   contractAddr,
+		"visible": visible,
+	}, &info)
+	if err != nil {
+		return nil, err
+	}
+	if info.SmartContract.ContractAddress == "" && info.RuntimeCode == "" {
+		return nil, ErrContractNotFound
+	}
+	return &info, nil
+}
+
+// UpdateSetting Update the percentage, from 0 to 100, of the energy of calls
+// of the contract paid by the caller. Only the origin address of the
+// contract may update it.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) UpdateSetting(ownerAddr, contractAddr string, consumeUserResourcePercent int64, visible bool) (*Transaction, error) {
+	if consumeUserResourcePercent < 0 || consumeUserResourcePercent > 100 {
+		return nil, ErrInvalidResourcePercent
+	}
+	return c.createTx("/wallet/updatesetting", map[string]interface{}{
+		"owner_address":                 ownerAddr,
+		"contract_address":              contractAddr,
+		"consume_user_resource_percent": consumeUserResourcePercent,
+		"visible":                       visible,
+	})
+}
+
+// UpdateEnergyLimit Update the most energy the origin address of the
+// contract pays per call. Only the origin address may update it.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) UpdateEnergyLimit(ownerAddr, contractAddr string, originEnergyLimit int64, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/updateenergylimit", map[string]interface{}{
+		"owner_address":       ownerAddr,
+		"contract_address":    contractAddr,
+		"origin_energy_limit": originEnergyLimit,
+		"visible":             visible,
+	})
+}
+
+// ClearContractABI Remove the ABI of the contract from the chain. Only the
+// origin address of the contract may clear it.
+// The returned transaction is verified locally with VerifyTx.
+func (c *Client) ClearContractABI(ownerAddr, contractAddr string, visible bool) (*Transaction, error) {
+	return c.createTx("/wallet/clearabi", map[string]interface{}{
+		"owner_address":    ownerAddr,
+		"contract_address": contractAddr,
+		"visible":          visible,
+	})
+}
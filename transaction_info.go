@@ -0,0 +1,166 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrTxNotFound is returned when the node does not know the requested
+// transaction, or has not executed it yet.
+var ErrTxNotFound = errors.New("transaction not found")
+
+// Transaction execution results, in TransactionInfo.Receipt.Result and
+// TransactionRet.ContractRet.
+const (
+	ContractResultSuccess     = "SUCCESS"
+	ContractResultRevert      = "REVERT"
+	ContractResultOutOfEnergy = "OUT_OF_ENERGY"
+	ContractResultOutOfTime   = "OUT_OF_TIME"
+)
+
+// TransactionInfo is the execution receipt of a transaction, as returned by
+// GetTransactionInfoByID. Fees are in SUN and times are unix timestamps in
+// milliseconds.
+type TransactionInfo struct {
+	ID             string   `json:"id"`
+	Fee            Sun      `json:"fee,omitempty"`
+	BlockNumber    int64    `json:"blockNumber,omitempty"`
+	BlockTimeStamp int64    `json:"blockTimeStamp,omitempty"`
+	ContractResult []string `json:"contractResult,omitempty"`
+	// ContractAddress is the called or created smart contract, hex encoded.
+	ContractAddress string          `json:"contract_address,omitempty"`
+	Receipt         ResourceReceipt `json:"receipt"`
+	Log             []Log           `json:"log,omitempty"`
+	// Result is FAILED when the transaction failed, and empty otherwise.
+	Result string `json:"result,omitempty"`
+	// ResMessage is the hex encoded failure message, see ResultMessage.
+	ResMessage           string                `json:"resMessage,omitempty"`
+	AssetIssueID         string                `json:"assetIssueID,omitempty"`
+	WithdrawAmount       Sun                   `json:"withdraw_amount,omitempty"`
+	UnfreezeAmount       Sun                   `json:"unfreeze_amount,omitempty"`
+	InternalTransactions []InternalTransaction `json:"internal_transactions,omitempty"`
+	WithdrawExpireAmount Sun                   `json:"withdraw_expire_amount,omitempty"`
+	PackingFee           Sun                   `json:"packingFee,omitempty"`
+}
+
+// ResourceReceipt is the bandwidth and energy consumed by a transaction.
+type ResourceReceipt struct {
+	EnergyUsage        int64  `json:"energy_usage,omitempty"`
+	EnergyFee          Sun    `json:"energy_fee,omitempty"`
+	OriginEnergyUsage  int64  `json:"origin_energy_usage,omitempty"`
+	EnergyUsageTotal   int64  `json:"energy_usage_total,omitempty"`
+	NetUsage           int64  `json:"net_usage,omitempty"`
+	NetFee             Sun    `json:"net_fee,omitempty"`
+	Result             string `json:"result,omitempty"`
+	EnergyPenaltyTotal int64  `json:"energy_penalty_total,omitempty"`
+}
+
+// Log is an event emitted by a smart contract. Address, topics and data are
+// hex encoded.
+type Log struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+// InternalTransaction is a call or transfer made by a smart contract.
+type InternalTransaction struct {
+	Hash              string          `json:"hash"`
+	CallerAddress     string          `json:"caller_address"`
+	TransferToAddress string          `json:"transferTo_address"`
+	CallValueInfo     []CallValueInfo `json:"callValueInfo,omitempty"`
+	// Note is the hex encoded kind of the internal transaction, e.g. "call".
+	Note     string `json:"note,omitempty"`
+	Rejected bool   `json:"rejected,omitempty"`
+	Extra    string `json:"extra,omitempty"`
+}
+
+// CallValueInfo is a TRX (empty TokenID) or TRC10 amount sent by an internal
+// transaction.
+type CallValueInfo struct {
+	CallValue int64  `json:"callValue,omitempty"`
+	TokenID   string `json:"tokenId,omitempty"`
+}
+
+// ResultMessage returns the hex decoded failure message of the transaction.
+func (i *TransactionInfo) ResultMessage() string {
+	return decodeHexText(i.ResMessage)
+}
+
+// Failed reports whether the transaction failed.
+func (i *TransactionInfo) Failed() bool {
+	return i.Result == "FAILED" ||
+		(i.Receipt.Result != "" && i.Receipt.Result != ContractResultSuccess)
+}
+
+// BlockTime returns the time of the block including the transaction.
+func (i *TransactionInfo) BlockTime() time.Time {
+	return msToTime(i.BlockTimeStamp)
+}
+
+// NoteText returns the hex decoded note of the internal transaction.
+func (t *InternalTransaction) NoteText() string {
+	return decodeHexText(t.Note)
+}
+
+// GetTransactionByID Query a transaction by its id. ErrTxNotFound is
+// returned when the node does not know it.
+func (c *Client) GetTransactionByID(txID string, visible bool, consistency Consistency) (*Transaction, error) {
+	var tx Transaction
+	err := c.post(readPath("gettransactionbyid", consistency), map[string]interface{}{
+		"value":   txID,
+		"visible": visible,
+	}, &tx)
+	if err != nil {
+		return nil, err
+	}
+	if tx.TxId == "" {
+		return nil, ErrTxNotFound
+	}
+	return &tx, nil
+}
+
+// GetTransactionInfoByID Query the execution receipt of a transaction.
+// ErrTxNotFound is returned until the transaction is included in a block,
+// or in a solidified block with Solidified.
+func (c *Client) GetTransactionInfoByID(txID string, consistency Consistency) (*TransactionInfo, error) {
+	return c.getTransactionInfoByID(context.Background(), txID, consistency)
+}
+
+func (c *Client) getTransactionInfoByID(ctx context.Context, txID string, consistency Consistency) (*TransactionInfo, error) {
+	var info TransactionInfo
+	err := c.postContext(ctx, readPath("gettransactioninfobyid", consistency), map[string]interface{}{
+		"value": txID,
+	}, &info)
+	if err != nil {
+		return nil, err
+	}
+	if info.ID == "" {
+		return nil, ErrTxNotFound
+	}
+	return &info, nil
+}
+
+// GetTransactionInfoByBlockNum Query the execution receipts of all the
+// transactions of a block.
+func (c *Client) GetTransactionInfoByBlockNum(num int64, consistency Consistency) ([]TransactionInfo, error) {
+	var raw json.RawMessage
+	err := c.post(readPath("gettransactioninfobyblocknum", consistency), map[string]interface{}{
+		"num": num,
+	}, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks without transactions are returned as an empty object.
+	var infos []TransactionInfo
+	if len(bytes.TrimSpace(raw)) > 0 && bytes.TrimSpace(raw)[0] == '[' {
+		if err := json.Unmarshal(raw, &infos); err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
@@ -0,0 +1,260 @@
+package tronhttpClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// AccountActivationFee is the fee burnt by a transfer to an account that
+// does not exist yet: 1 TRX to create it plus 0.1 TRX of bandwidth.
+const AccountActivationFee Sun = 1100000
+
+// BandwidthPrice is the fee burnt per byte of a transaction when the sender
+// has not enough bandwidth left.
+const BandwidthPrice Sun = 1000
+
+// MemoFee is the fee burnt by a transaction with a memo, the getMemoFee
+// chain parameter.
+const MemoFee Sun = 1000000
+
+// txResultSize is the size the node reserves for the result of each
+// contract when charging the bandwidth of a transaction.
+const txResultSize = 64
+
+var (
+	// ErrNonPositiveAmount is returned when transferring a non positive
+	// amount.
+	ErrNonPositiveAmount = errors.New("amount must be positive")
+	// ErrSelfTransfer is returned when transferring to the sender.
+	ErrSelfTransfer = errors.New("cannot transfer to the sender")
+	// ErrRecipientNotActivated is returned when the recipient does not exist
+	// and TransferOptions.RequireActivated is set.
+	ErrRecipientNotActivated = errors.New("recipient account is not activated")
+)
+
+// InsufficientBalanceError is returned when the confirmed balance of the
+// sender does not cover the amount and the estimated fees.
+type InsufficientBalanceError struct {
+	Address  string
+	Balance  Sun
+	Required Sun
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("insufficient balance of %s: %s, %s required", e.Address, e.Balance, e.Required)
+}
+
+// broadcast sends tx with BroadcastTx and returns whether the node already
+// had it. Refusals other than duplicates are returned as a *BroadcastError.
+func (c *Client) broadcast(ctx context.Context, tx *Transaction) (bool, error) {
+	err := c.broadcastTx(ctx, tx)
+	var refused *BroadcastError
+	if errors.As(err, &refused) && refused.Code == BroadcastDuplicateError {
+		return true, nil
+	}
+	return false, err
+}
+
+// accountNet is the bandwidth part of the response of
+// /wallet/getaccountresource. Zero fields are omitted by the node.
+type accountNet struct {
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+}
+
+// covers reports whether the bandwidth left covers a transaction of size
+// bytes: the node consumes the staked bandwidth, or else the free
+// bandwidth, and only burns TRX when neither is enough.
+func (n *accountNet) covers(size int64) bool {
+	return n.NetLimit-n.NetUsed >= size || n.FreeNetLimit-n.FreeNetUsed >= size
+}
+
+func (c *Client) getAccountNet(ctx context.Context, address string) (*accountNet, error) {
+	var net accountNet
+	err := c.postContext(ctx, "/wallet/getaccountresource", map[string]interface{}{
+		"address": address,
+		"visible": true,
+	}, &net)
+	if err != nil {
+		return nil, err
+	}
+	return &net, nil
+}
+
+// transferFee estimates the fee burnt by the signed transfer tx: the
+// bandwidth of the transaction, unless net, the bandwidth left to the
+// sender, covers it, and the memo fee.
+func transferFee(tx *Transaction, net *accountNet) (Sun, error) {
+	// Field tag and length prefix of the raw data, then of each signature.
+	size := int64(len(tx.RawDataHex)/2 + 3 + txResultSize)
+	for _, sig := range tx.Signature {
+		size += int64(len(sig)/2 + 2)
+	}
+
+	var fee Sun
+	if !net.covers(size) {
+		var err error
+		if fee, err = BandwidthPrice.Mul(size); err != nil {
+			return 0, err
+		}
+	}
+	if tx.RawData.Data != "" {
+		return fee.Add(MemoFee)
+	}
+	return fee, nil
+}
+
+// TransferOptions are the options of Transfer.
+type TransferOptions struct {
+	// Memo is stored in the data field of the transaction.
+	Memo string
+	// Expiration is how long after being built the transaction expires,
+	// DefaultExpiration when zero.
+	Expiration time.Duration
+	// RequireActivated refuses transfers to accounts that do not exist yet,
+	// instead of paying AccountActivationFee to create them.
+	RequireActivated bool
+	// Confirm waits for the confirmation of the transaction with
+	// WaitForConfirmation when not nil.
+	Confirm *ConfirmOptions
+}
+
+// TransferReceipt is the result of Transfer.
+type TransferReceipt struct {
+	TxID   string
+	From   string
+	To     string
+	Amount Sun
+	// Activated reports whether the recipient existed before the transfer.
+	Activated bool
+	// Duplicate reports whether the node already had the transaction.
+	Duplicate   bool
+	Transaction *Transaction
+	// Info is the receipt of the transaction, only set when it was
+	// confirmed with TransferOptions.Confirm.
+	Info *TransactionInfo
+}
+
+// Transfer sends amount TRX from the address of signer to to: the inputs
+// are checked, the transaction is built and signed locally, the confirmed
+// balance of the sender is checked against the amount and the estimated
+// fees (activation, bandwidth unless the free or staked bandwidth of the
+// sender covers it, and memo), then the transaction is broadcast and
+// optionally confirmed. The
+// private key never leaves the process. The node answering
+// DUP_TRANSACTION_ERROR, e.g. when a broadcast that had reached it is
+// retried by the HTTP client, is not an error but sets Duplicate. The
+// requests to the node, and their retries, are abandoned once ctx is done.
+func (c *Client) Transfer(ctx context.Context, signer Signer, to string, amount Sun, opts *TransferOptions) (*TransferReceipt, error) {
+	if opts == nil {
+		opts = &TransferOptions{}
+	}
+	from := signer.Address()
+
+	toAddr, err := address.Decode(to)
+	if err != nil {
+		return nil, fmt.Errorf("recipient: %w", err)
+	}
+	to = address.ToBase58(toAddr)
+	if amount <= 0 {
+		return nil, ErrNonPositiveAmount
+	}
+	if to == from {
+		return nil, ErrSelfTransfer
+	}
+
+	recipient, err := c.getAccount(ctx, to, true, Latest)
+	if err != nil {
+		return nil, err
+	}
+	activated := recipient.Address != ""
+	if !activated && opts.RequireActivated {
+		return nil, ErrRecipientNotActivated
+	}
+
+	refBlock, err := c.getNowBlock(ctx, true, Solidified)
+	if err != nil {
+		return nil, err
+	}
+	builder := NewTxBuilder(refBlock.RefBlock())
+	builder.SetVisible(true)
+	if opts.Expiration != 0 {
+		if err := builder.SetExpiration(opts.Expiration); err != nil {
+			return nil, err
+		}
+	}
+
+	raw := RawData{Contract: []Contract{NewContract(TransferContract, &TransferContractValue{
+		OwnerAddress: from,
+		ToAddress:    to,
+		Amount:       amount,
+	})}}
+	if opts.Memo != "" {
+		raw.SetMemo(opts.Memo)
+	}
+	tx, err := builder.Build(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Sign(tx); err != nil {
+		return nil, err
+	}
+
+	// The activation fee includes the bandwidth of the transaction.
+	fee := AccountActivationFee
+	if activated {
+		net, err := c.getAccountNet(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		if fee, err = transferFee(tx, net); err != nil {
+			return nil, err
+		}
+	} else if tx.RawData.Data != "" {
+		if fee, err = fee.Add(MemoFee); err != nil {
+			return nil, err
+		}
+	}
+	required, err := amount.Add(fee)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := c.getAccount(ctx, from, true, Solidified)
+	if err != nil {
+		return nil, err
+	}
+	if sender.Balance < required {
+		return nil, &InsufficientBalanceError{Address: from, Balance: sender.Balance, Required: required}
+	}
+
+	duplicate, err := c.broadcast(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &TransferReceipt{
+		TxID:        tx.TxId,
+		From:        from,
+		To:          to,
+		Amount:      amount,
+		Activated:   activated,
+		Duplicate:   duplicate,
+		Transaction: tx,
+	}
+	if opts.Confirm == nil {
+		return receipt, nil
+	}
+
+	confirm := *opts.Confirm
+	confirm.Expiration = tx.ExpiresAt()
+	if receipt.Info, err = c.WaitForConfirmation(ctx, tx.TxId, &confirm); err != nil {
+		return receipt, err
+	}
+	return receipt, nil
+}
@@ -0,0 +1,40 @@
+package tronhttpClient
+
+import "testing"
+
+func TestTransferFee(t *testing.T) {
+	txHex, _ := signedTransferHex(t)
+	tx, err := TransactionFromHex(txHex, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Raw data and signature, with their field tags and length prefixes,
+	// and the result reserved by the node.
+	size := int64(len(tx.RawDataHex)/2+3) + 65 + 2 + txResultSize
+	burnt := BandwidthPrice * Sun(size)
+
+	tests := []struct {
+		name string
+		net  accountNet
+		memo bool
+		want Sun
+	}{
+		{"no bandwidth", accountNet{}, false, burnt},
+		{"free bandwidth", accountNet{FreeNetLimit: 600}, false, 0},
+		{"free bandwidth used", accountNet{FreeNetLimit: 600, FreeNetUsed: 600 - size + 1}, false, burnt},
+		{"staked bandwidth", accountNet{FreeNetLimit: 600, FreeNetUsed: 600, NetLimit: size}, false, 0},
+		{"staked bandwidth used", accountNet{NetLimit: 5000, NetUsed: 5000 - size + 1}, false, burnt},
+		{"memo", accountNet{FreeNetLimit: 600}, true, MemoFee},
+		{"memo without bandwidth", accountNet{}, true, burnt + MemoFee},
+	}
+	for _, tt := range tests {
+		tx := *tx
+		if tt.memo {
+			tx.RawData.Data = "6d656d6f"
+		}
+		got, err := transferFee(&tx, &tt.net)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: transferFee = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
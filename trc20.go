@@ -0,0 +1,335 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/stdevHsequeda/TRONHttpClient/abi"
+	"github.com/stdevHsequeda/TRONHttpClient/address"
+)
+
+// TransferEventTopic is the topic of the TRC20 Transfer(address,address,
+// uint256) event, the keccak256 of its signature.
+const TransferEventTopic = "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// Types of the arguments of the Transfer event.
+var (
+	addressType = abi.Type{Kind: abi.AddressKind}
+	uint256Type = abi.Type{Kind: abi.UintKind, Size: 256}
+)
+
+// ErrPrecisionLoss is returned when an amount has more decimals than the
+// token.
+var ErrPrecisionLoss = errors.New("amount has more decimals than the token")
+
+// TRC20 is a handle on a TRC20 token contract. Reads are constant calls,
+// which cost nothing. Writes return the unsigned transaction, to be signed
+// with SignTx and broadcast with BroadcastTx.
+type TRC20 struct {
+	client   *Client
+	contract string
+
+	mu       sync.Mutex
+	decimals *int
+}
+
+// TRC20Options are the options of the TRC20 transactions.
+type TRC20Options struct {
+	// FeeLimit is the most TRX the call may burn for energy, DefaultFeeLimit
+	// when zero.
+	FeeLimit Sun
+	// PermissionID is the permission of the owner signing the transaction.
+	PermissionID int
+}
+
+// TRC20Transfer is a decoded TRC20 Transfer event.
+type TRC20Transfer struct {
+	From  string
+	To    string
+	Value TokenAmount
+}
+
+// TRC20 returns a handle on the TRC20 token at contract, a base58 or hex
+// address.
+func (c *Client) TRC20(contract string) (*TRC20, error) {
+	b, err := address.Decode(contract)
+	if err != nil {
+		return nil, err
+	}
+	return &TRC20{client: c, contract: address.ToBase58(b)}, nil
+}
+
+// Address returns the base58 address of the token contract.
+func (t *TRC20) Address() string {
+	return t.contract
+}
+
+// call makes a constant call of the function sig, e.g.
+// "balanceOf(address) returns (uint256)", with args and returns the raw
+// output along with its decoding.
+func (t *TRC20) call(sig string, consistency Consistency, args ...interface{}) ([]byte, []interface{}, error) {
+	m, err := abi.ParseMethod(sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	param, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := t.client.triggerConstant(&triggerCall{
+		OwnerAddress:    t.contract,
+		ContractAddress: t.contract,
+		Selector:        m.Sig,
+		Parameter:       hex.EncodeToString(param),
+		Visible:         true,
+	}, consistency)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.ConstantResult) == 0 {
+		return nil, nil, abi.ErrInvalidData
+	}
+	out, err := hex.DecodeString(resp.ConstantResult[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := m.Outputs.Unpack(out)
+	return out, values, err
+}
+
+// callUint makes a constant call of the function sig returning a uint.
+func (t *TRC20) callUint(sig string, consistency Consistency, args ...interface{}) (*big.Int, error) {
+	_, values, err := t.call(sig, consistency, args...)
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
+
+// Decimals returns the decimals of the token. It is only queried once.
+func (t *TRC20) Decimals() (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.decimals != nil {
+		return *t.decimals, nil
+	}
+
+	v, err := t.callUint("decimals() returns (uint8)", Latest)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() || v.Int64() > 77 {
+		return 0, abi.ErrInvalidData
+	}
+	d := int(v.Int64())
+	t.decimals = &d
+	return d, nil
+}
+
+// amount returns the TokenAmount of v smallest units of the token.
+func (t *TRC20) amount(v *big.Int) (TokenAmount, error) {
+	d, err := t.Decimals()
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return TokenAmount{Value: v, Decimals: d}, nil
+}
+
+// units returns amount in smallest units of the token, rescaling it when
+// its decimals differ from the decimals of the token.
+func (t *TRC20) units(amount TokenAmount) (*big.Int, error) {
+	if amount.Value == nil || amount.Value.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+	d, err := t.Decimals()
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(big.Int).Set(amount.Value)
+	switch {
+	case amount.Decimals < d:
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d-amount.Decimals)), nil))
+	case amount.Decimals > d:
+		var rem big.Int
+		v.QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(amount.Decimals-d)), nil), &rem)
+		if rem.Sign() != 0 {
+			return nil, ErrPrecisionLoss
+		}
+	}
+	return v, nil
+}
+
+// callString makes a constant call of the function sig returning a string.
+// Contracts returning a bytes32 instead, such as some old tokens, are
+// decoded with their trailing zeros trimmed.
+func (t *TRC20) callString(sig string) (string, error) {
+	out, values, err := t.call(sig, Latest)
+	if len(out) == 32 {
+		return strings.TrimRight(string(out), "\x00"), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+// Name returns the name of the token.
+func (t *TRC20) Name() (string, error) {
+	return t.callString("name() returns (string)")
+}
+
+// Symbol returns the symbol of the token.
+func (t *TRC20) Symbol() (string, error) {
+	return t.callString("symbol() returns (string)")
+}
+
+// TotalSupply returns the total supply of the token.
+func (t *TRC20) TotalSupply() (TokenAmount, error) {
+	v, err := t.callUint("totalSupply() returns (uint256)", Latest)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return t.amount(v)
+}
+
+// BalanceOf returns the balance of owner. Use Solidified for balances that
+// decisions such as withdrawals rely on.
+func (t *TRC20) BalanceOf(owner string, consistency Consistency) (TokenAmount, error) {
+	v, err := t.callUint("balanceOf(address) returns (uint256)", consistency, owner)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return t.amount(v)
+}
+
+// Allowance returns the amount spender may still transfer from owner.
+func (t *TRC20) Allowance(owner, spender string, consistency Consistency) (TokenAmount, error) {
+	v, err := t.callUint("allowance(address,address) returns (uint256)", consistency, owner, spender)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return t.amount(v)
+}
+
+// send creates the transaction calling the function sig from owner with
+// args.
+func (t *TRC20) send(owner, sig string, opts *TRC20Options, args ...interface{}) (*Transaction, error) {
+	if opts == nil {
+		opts = &TRC20Options{}
+	}
+	m, err := abi.ParseMethod(sig)
+	if err != nil {
+		return nil, err
+	}
+	param, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.triggerSmart(&triggerCall{
+		OwnerAddress:    owner,
+		ContractAddress: t.contract,
+		Selector:        m.Sig,
+		Parameter:       hex.EncodeToString(param),
+		FeeLimit:        opts.FeeLimit,
+		PermissionID:    opts.PermissionID,
+		Visible:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transaction, nil
+}
+
+// Transfer creates the transaction transferring amount from from to to.
+// amount is rescaled to the decimals of the token, see ParseTokenAmount.
+// The returned transaction is verified locally with VerifyTx.
+func (t *TRC20) Transfer(from, to string, amount TokenAmount, opts *TRC20Options) (*Transaction, error) {
+	v, err := t.units(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(from, "transfer(address,uint256)", opts, to, v)
+}
+
+// Approve creates the transaction allowing spender to transfer up to amount
+// from owner.
+// The returned transaction is verified locally with VerifyTx.
+func (t *TRC20) Approve(owner, spender string, amount TokenAmount, opts *TRC20Options) (*Transaction, error) {
+	v, err := t.units(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(owner, "approve(address,uint256)", opts, spender, v)
+}
+
+// TransferFrom creates the transaction in which spender transfers amount
+// from from to to, within the allowance given by from.
+// The returned transaction is verified locally with VerifyTx.
+func (t *TRC20) TransferFrom(spender, from, to string, amount TokenAmount, opts *TRC20Options) (*Transaction, error) {
+	v, err := t.units(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(spender, "transferFrom(address,address,uint256)", opts, from, to, v)
+}
+
+// TransferEvents returns the Transfer events emitted by the token in the
+// receipt info, e.g. from GetTransactionInfoByID.
+func (t *TRC20) TransferEvents(info *TransactionInfo) ([]TRC20Transfer, error) {
+	contract, _ := address.Decode(t.contract)
+	contractHex := hex.EncodeToString(contract[1:])
+
+	var events []TRC20Transfer
+	for _, l := range info.Log {
+		// Log addresses are 20 bytes, without the 0x41 prefix.
+		if strings.TrimPrefix(strings.ToLower(l.Address), "41") != contractHex &&
+			strings.ToLower(l.Address) != contractHex {
+			continue
+		}
+		if len(l.Topics) != 3 || strings.ToLower(l.Topics[0]) != TransferEventTopic {
+			continue
+		}
+
+		from, err := topicAddress(l.Topics[1])
+		if err != nil {
+			return nil, err
+		}
+		to, err := topicAddress(l.Topics[2])
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(l.Data)
+		if err != nil {
+			return nil, err
+		}
+		values, err := abi.Decode([]abi.Type{uint256Type}, data)
+		if err != nil {
+			return nil, err
+		}
+		value, err := t.amount(values[0].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, TRC20Transfer{From: from, To: to, Value: value})
+	}
+	return events, nil
+}
+
+// topicAddress decodes an indexed address event topic to a base58 address.
+func topicAddress(topic string) (string, error) {
+	b, err := hex.DecodeString(topic)
+	if err != nil {
+		return "", err
+	}
+	values, err := abi.Decode([]abi.Type{addressType}, b)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
@@ -0,0 +1,161 @@
+package tronhttpClient
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stdevHsequeda/TRONHttpClient/abi"
+)
+
+// DefaultFeeLimit is the fee limit of smart contract calls when none is
+// given: the most TRX the call may burn for energy.
+const DefaultFeeLimit Sun = 100000000
+
+// TriggerError is returned when the node refuses or fails to execute a smart
+// contract call.
+type TriggerError struct {
+	Code    string
+	Message string
+}
+
+func (e *TriggerError) Error() string {
+	if e.Code == "" {
+		return "contract call failed: " + e.Message
+	}
+	return fmt.Sprintf("contract call failed: %s: %s", e.Code, e.Message)
+}
+
+// RevertError is returned when a constant call reverts. Reason is the
+// decoded revert reason, empty when the contract gave none.
+type RevertError struct {
+	Reason string
+	// Data is the raw revert data.
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
+
+// TriggerResult is the status returned by the trigger endpoints. Message is
+// hex encoded, see MessageText.
+type TriggerResult struct {
+	Result  bool   `json:"result"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// MessageText returns the hex decoded message of r.
+func (r *TriggerResult) MessageText() string {
+	return decodeHexText(r.Message)
+}
+
+// triggerResponse is the response of /wallet/triggersmartcontract and
+// /wallet/triggerconstantcontract.
+type triggerResponse struct {
+	Result         TriggerResult `json:"result"`
+	EnergyUsed     int64         `json:"energy_used,omitempty"`
+	ConstantResult []string      `json:"constant_result,omitempty"`
+	Transaction    *Transaction  `json:"transaction,omitempty"`
+}
+
+// triggerCall is a smart contract call. Parameter is the hex encoded ABI
+// arguments of Selector, e.g. "transfer(address,uint256)".
+type triggerCall struct {
+	OwnerAddress    string
+	ContractAddress string
+	Selector        string
+	Parameter       string
+	CallValue       Sun
+	CallTokenValue  int64
+	TokenID         int64
+	FeeLimit        Sun
+	PermissionID    int
+	Visible         bool
+}
+
+func (t *triggerCall) params() map[string]interface{} {
+	params := map[string]interface{}{
+		"owner_address":     t.OwnerAddress,
+		"contract_address":  t.ContractAddress,
+		"function_selector": t.Selector,
+		"parameter":         t.Parameter,
+		"visible":           t.Visible,
+	}
+	if t.CallValue != 0 {
+		params["call_value"] = t.CallValue
+	}
+	if t.CallTokenValue != 0 {
+		params["call_token_value"] = t.CallTokenValue
+		params["token_id"] = t.TokenID
+	}
+	if t.PermissionID != 0 {
+		params["Permission_id"] = t.PermissionID
+	}
+	return params
+}
+
+// triggerConstant executes call without creating a transaction and returns
+// the response with the revert and failure checks done. On revert, the
+// response is returned along with the *RevertError.
+func (c *Client) triggerConstant(call *triggerCall, consistency Consistency) (*triggerResponse, error) {
+	var resp triggerResponse
+	if err := c.post(readPath("triggerconstantcontract", consistency), call.params(), &resp); err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if len(resp.ConstantResult) > 0 {
+		b, err := hex.DecodeString(resp.ConstantResult[0])
+		if err != nil {
+			return nil, err
+		}
+		out = b
+	}
+
+	// Depending on its version, the node reports reverts as a failed result
+	// or as a failed transaction.
+	failed := resp.Transaction != nil && len(resp.Transaction.Ret) > 0 && resp.Transaction.Ret[0].Ret == "FAILED"
+	if reason := decodeRevertReason(out); failed || (!resp.Result.Result && reason != "") {
+		return &resp, &RevertError{Reason: reason, Data: out}
+	}
+	if !resp.Result.Result {
+		return nil, &TriggerError{Code: resp.Result.Code, Message: resp.Result.MessageText()}
+	}
+	return &resp, nil
+}
+
+// triggerSmart creates the transaction of call, verified with VerifyTx.
+func (c *Client) triggerSmart(call *triggerCall) (*triggerResponse, error) {
+	params := call.params()
+	feeLimit := call.FeeLimit
+	if feeLimit == 0 {
+		feeLimit = DefaultFeeLimit
+	}
+	params["fee_limit"] = feeLimit
+
+	var resp triggerResponse
+	if err := c.post("/wallet/triggersmartcontract", params, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Result.Result || resp.Transaction == nil {
+		return nil, &TriggerError{Code: resp.Result.Code, Message: resp.Result.MessageText()}
+	}
+	if err := VerifyTx(resp.Transaction); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// decodeRevertReason returns the reason of Error(string) or Panic(uint256)
+// revert data, or "" when data is neither, see abi.DecodeRevert.
+func decodeRevertReason(data []byte) string {
+	reason, err := abi.DecodeRevert(data)
+	if err != nil {
+		return ""
+	}
+	return reason
+}
@@ -1,11 +1,47 @@
 package tronhttpClient
 
+import "encoding/hex"
+
 type Transaction struct {
-	Visible    bool        `json:"visible"`
-	TxId       string      `json:"txID"`
-	RawData    interface{} `json:"raw_data"`
-	RawDataHex string      `json:"raw_data_hex"`
-	Signature  []string    `json:"signature"`
+	Visible    bool     `json:"visible"`
+	TxId       string   `json:"txID"`
+	RawData    RawData  `json:"raw_data"`
+	RawDataHex string   `json:"raw_data_hex"`
+	Signature  []string `json:"signature"`
+	// Ret is the execution result of each contract, only set on the
+	// transactions returned by the block and transaction queries.
+	Ret []TransactionRet `json:"ret,omitempty"`
+}
+
+// TransactionRet is the execution result of a transaction contract.
+type TransactionRet struct {
+	Ret         string `json:"ret,omitempty"`
+	Fee         Sun    `json:"fee,omitempty"`
+	ContractRet string `json:"contractRet,omitempty"`
+}
+
+// RawData is the JSON form of the Transaction.raw protobuf message, the
+// signed part of a transaction. Byte fields are hex encoded and times are
+// unix timestamps in milliseconds.
+type RawData struct {
+	RefBlockBytes string     `json:"ref_block_bytes,omitempty"`
+	RefBlockNum   int64      `json:"ref_block_num,omitempty"`
+	RefBlockHash  string     `json:"ref_block_hash,omitempty"`
+	Expiration    int64      `json:"expiration,omitempty"`
+	Data          string     `json:"data,omitempty"`
+	Contract      []Contract `json:"contract,omitempty"`
+	Timestamp     int64      `json:"timestamp,omitempty"`
+	FeeLimit      Sun        `json:"fee_limit,omitempty"`
+}
+
+// Contract is a single contract (operation) of a transaction.
+type Contract struct {
+	Type         ContractType      `json:"type"`
+	Parameter    ContractParameter `json:"parameter"`
+	PermissionID int               `json:"Permission_id,omitempty"`
+	// TypeName is the type name found in JSON when Type is UnknownContract,
+	// and the one written back.
+	TypeName string `json:"-"`
 }
 
 type Address struct {
@@ -19,46 +55,130 @@ type AddressWithoutPrivKey struct {
 	Value              string `json:"value"`
 }
 
+// Account is an account as returned by GetAccount. Times are unix
+// timestamps in milliseconds, see the time.Time helpers in account.go.
 type Account struct {
-	Address               string          `json:"address"`
-	Balance               int             `json:"balance"`
-	Frozen                []Frozen        `json:"frozen"`
-	CreateTime            int             `json:"create_time"`
-	LatestOperationTime   int             `json:"latest_opration_time"`
-	FreeNetUsage          int             `json:"free_net_usage"`
-	LatestConsumeFreeTime int             `json:"latest_consume_free_time"`
-	AccountResource       AccountResource `json:"account_resource"`
-	OwnerPermission       Permission      `json:"owner_permission"`
-	ActivePermission      []Permission    `json:"active_permission"`
-	AssetV2               []Asset         `json:"assetV2"`
-	FreeAssetNetUsageV2   []Asset         `json:"free_asset_net_usageV2"`
+	AccountName string `json:"account_name"`
+	Type        string `json:"type"`
+	Address     string `json:"address"`
+	AccountID   string `json:"account_id"`
+	Balance     Sun    `json:"balance"`
+	Votes       []Vote `json:"votes"`
+	Allowance   Sun    `json:"allowance"`
+	IsWitness   bool   `json:"is_witness"`
+	IsCommittee bool   `json:"is_committee"`
+
+	CreateTime            int64 `json:"create_time"`
+	LatestOperationTime   int64 `json:"latest_opration_time"` // sic, as spelled by the node
+	LatestWithdrawTime    int64 `json:"latest_withdraw_time"`
+	LatestConsumeTime     int64 `json:"latest_consume_time"`
+	LatestConsumeFreeTime int64 `json:"latest_consume_free_time"`
+
+	NetUsage           int64 `json:"net_usage"`
+	FreeNetUsage       int64 `json:"free_net_usage"`
+	NetWindowSize      int64 `json:"net_window_size"`
+	NetWindowOptimized bool  `json:"net_window_optimized"`
+
+	// Stake 1.0, deprecated.
+	Frozen                                     []Frozen `json:"frozen"`
+	DelegatedFrozenBalanceForBandwidth         Sun      `json:"delegated_frozen_balance_for_bandwidth"`
+	AcquiredDelegatedFrozenBalanceForBandwidth Sun      `json:"acquired_delegated_frozen_balance_for_bandwidth"`
+	OldTronPower                               int64    `json:"old_tron_power"`
+	TronPower                                  Frozen   `json:"tron_power"`
+
+	// Stake 2.0.
+	FrozenV2                                     []FrozenV2   `json:"frozenV2"`
+	UnfrozenV2                                   []UnfrozenV2 `json:"unfrozenV2"`
+	DelegatedFrozenV2BalanceForBandwidth         Sun          `json:"delegated_frozenV2_balance_for_bandwidth"`
+	AcquiredDelegatedFrozenV2BalanceForBandwidth Sun          `json:"acquired_delegated_frozenV2_balance_for_bandwidth"`
+
+	AccountResource AccountResource `json:"account_resource"`
+
+	OwnerPermission   Permission   `json:"owner_permission"`
+	WitnessPermission *Permission  `json:"witness_permission,omitempty"`
+	ActivePermission  []Permission `json:"active_permission"`
+
+	AssetIssuedName            string   `json:"asset_issued_name"`
+	AssetIssuedID              string   `json:"asset_issued_ID"`
+	AssetOptimized             bool     `json:"asset_optimized"`
+	AssetV2                    []Asset  `json:"assetV2"`
+	FreeAssetNetUsageV2        []Asset  `json:"free_asset_net_usageV2"`
+	LatestAssetOperationTimeV2 []Asset  `json:"latest_asset_operation_timeV2"`
+	FrozenSupply               []Frozen `json:"frozen_supply"`
+
+	CodeHash string `json:"codeHash"`
+
+	// Visible is the visible flag the account was queried with: the bytes
+	// fields, such as the account name, are plain text instead of hex.
+	Visible bool `json:"-"`
 }
 
 type Permission struct {
-	Id             int    `json:"id"`
-	Type           string `json:"type"`
-	Operations     string `json:"operations"`
-	PermissionName string `json:"permission_name"`
-	Threshold      int    `json:"threshold"`
-	Keys           []Key  `json:"keys"`
+	Id             int        `json:"id,omitempty" pb:"2,varint"`
+	Type           string     `json:"type,omitempty" pb:"1,permission_type"`
+	Operations     Operations `json:"operations,omitempty" pb:"6,bytes"`
+	PermissionName string     `json:"permission_name,omitempty" pb:"3,string"`
+	Threshold      int64      `json:"threshold,omitempty" pb:"4,varint"`
+	Keys           []Key      `json:"keys,omitempty" pb:"7,message"`
 }
 
 type Key struct {
-	Address string `json:"address"`
-	Weight  int    `json:"weight"`
+	Address string `json:"address,omitempty" pb:"1,address"`
+	Weight  int64  `json:"weight,omitempty" pb:"2,varint"`
 }
 
 type Frozen struct {
-	FrozenBalance int `json:"frozen_balance"`
-	ExpireTime    int `json:"expire_time"`
+	FrozenBalance Sun   `json:"frozen_balance"`
+	ExpireTime    int64 `json:"expire_time"`
+}
+
+// FrozenV2 is the balance staked for a resource with stake 2.0. Type is
+// omitted for BANDWIDTH.
+type FrozenV2 struct {
+	Type   string `json:"type"`
+	Amount Sun    `json:"amount"`
+}
+
+// UnfrozenV2 is a balance being unstaked, withdrawable after
+// UnfreezeExpireTime.
+type UnfrozenV2 struct {
+	Type               string `json:"type"`
+	UnfreezeAmount     Sun    `json:"unfreeze_amount"`
+	UnfreezeExpireTime int64  `json:"unfreeze_expire_time"`
 }
 
 type AccountResource struct {
-	FrozenBalanceForEnergy     Frozen `json:"frozen_balance_for_energy"`
-	LatestConsumeTimeForEnergy int    `json:"latest_consume_time_for_energy"`
+	EnergyUsage                               int64  `json:"energy_usage"`
+	FrozenBalanceForEnergy                    Frozen `json:"frozen_balance_for_energy"`
+	LatestConsumeTimeForEnergy                int64  `json:"latest_consume_time_for_energy"`
+	DelegatedFrozenBalanceForEnergy           Sun    `json:"delegated_frozen_balance_for_energy"`
+	AcquiredDelegatedFrozenBalanceForEnergy   Sun    `json:"acquired_delegated_frozen_balance_for_energy"`
+	DelegatedFrozenV2BalanceForEnergy         Sun    `json:"delegated_frozenV2_balance_for_energy"`
+	AcquiredDelegatedFrozenV2BalanceForEnergy Sun    `json:"acquired_delegated_frozenV2_balance_for_energy"`
+	StorageLimit                              int64  `json:"storage_limit"`
+	StorageUsage                              int64  `json:"storage_usage"`
+	LatestExchangeStorageTime                 int64  `json:"latest_exchange_storage_time"`
+	EnergyWindowSize                          int64  `json:"energy_window_size"`
+	EnergyWindowOptimized                     bool   `json:"energy_window_optimized"`
 }
 
 type Asset struct {
 	Key   string `json:"key"`
-	Value int    `json:"value"`
+	Value int64  `json:"value"`
+}
+
+// SetMemo stores memo in the data field of raw, hex encoded.
+func (raw *RawData) SetMemo(memo string) {
+	raw.Data = hex.EncodeToString([]byte(memo))
+}
+
+// Memo returns the memo stored in the data field of raw. Data is hex encoded
+// by the node, but it is returned as is when it is not valid hex.
+func (raw *RawData) Memo() string {
+	return decodeHexText(raw.Data)
+}
+
+// Memo returns the memo of tx, see RawData.Memo.
+func (tx *Transaction) Memo() string {
+	return tx.RawData.Memo()
 }
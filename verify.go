@@ -0,0 +1,248 @@
+package tronhttpClient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnverifiable is returned, wrapped, by VerifyTx when the txID of a
+// transaction matches its raw_data_hex but a contract of its raw_data can
+// not be compared with it, the parameter value of its type having no
+// registered struct. Such a transaction is not known to be tampered with:
+// the caller may still choose to sign it, see SignOptions.
+var ErrUnverifiable = errors.New("raw_data can not be compared with raw_data_hex")
+
+// TxIntegrityError is returned when a transaction is not self consistent:
+// its txID is not the hash of raw_data_hex, or raw_data, which is what is
+// displayed, differs from raw_data_hex, which is what is signed.
+type TxIntegrityError struct {
+	TxID  string
+	Field string
+	// Signed is the value encoded in raw_data_hex (or its hash for txID).
+	Signed string
+	// Claimed is the value claimed by the node in txID or raw_data.
+	Claimed string
+}
+
+func (e *TxIntegrityError) Error() string {
+	return fmt.Sprintf("transaction %s: %s mismatch: raw_data_hex has %q but node claims %q",
+		e.TxID, e.Field, e.Signed, e.Claimed)
+}
+
+// VerifyTx checks locally that the SHA-256 of tx.RawDataHex is tx.TxId and
+// that tx.RawData, once encoded, is byte for byte raw_data_hex: what is
+// displayed is what is signed, for every field of every contract. Contracts
+// of a type without a registered value struct can not be encoded: when the
+// rest of the transaction is consistent, VerifyTx returns an error wrapping
+// ErrUnverifiable rather than a *TxIntegrityError.
+func VerifyTx(tx *Transaction) error {
+	if err := verifyTxID(tx); err != nil {
+		return err
+	}
+
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return err
+	}
+	signed, err := decodeRaw(raw)
+	if err != nil {
+		return err
+	}
+
+	claimed := &tx.RawData
+	mismatch := func(field, signed, claimed string) error {
+		return &TxIntegrityError{TxID: tx.TxId, Field: field, Signed: signed, Claimed: claimed}
+	}
+	checkInt := func(field string, signed, claimed int64) error {
+		if signed != claimed {
+			return mismatch(field, strconv.FormatInt(signed, 10), strconv.FormatInt(claimed, 10))
+		}
+		return nil
+	}
+	checkHex := func(field string, signed []byte, claimed string) error {
+		if hex.EncodeToString(signed) != strings.ToLower(claimed) {
+			return mismatch(field, hex.EncodeToString(signed), claimed)
+		}
+		return nil
+	}
+
+	// The fields are compared one by one first, for a precise error.
+	for _, err := range []error{
+		checkHex("ref_block_bytes", signed.refBlockBytes, claimed.RefBlockBytes),
+		checkHex("ref_block_hash", signed.refBlockHash, claimed.RefBlockHash),
+		checkHex("data", signed.data, claimed.Data),
+		checkInt("expiration", signed.expiration, claimed.Expiration),
+		checkInt("timestamp", signed.timestamp, claimed.Timestamp),
+		checkInt("fee_limit", signed.feeLimit, int64(claimed.FeeLimit)),
+		checkInt("contract count", int64(len(signed.contracts)), int64(len(claimed.Contract))),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	var unverifiable error
+	for i, sc := range signed.contracts {
+		cc := &claimed.Contract[i]
+		prefix := fmt.Sprintf("contract[%d].", i)
+
+		if sc.typeURL != cc.Parameter.TypeURL {
+			return mismatch(prefix+"type_url", sc.typeURL, cc.Parameter.TypeURL)
+		}
+		if sc.typeURL != cc.typeURL() {
+			return mismatch(prefix+"type", sc.typeURL, cc.typeURL())
+		}
+		if err := checkInt(prefix+"Permission_id", sc.permissionID, int64(cc.PermissionID)); err != nil {
+			return err
+		}
+
+		value, err := encodeContractValue(cc, tx.Visible)
+		if errors.Is(err, errNoValueType) {
+			// The other contracts are still checked for a mismatch.
+			if unverifiable == nil {
+				unverifiable = fmt.Errorf("transaction %s: contract[%d] %s: %w", tx.TxId, i, cc.Type, ErrUnverifiable)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("transaction %s: %s%w", tx.TxId, prefix, err)
+		}
+		if !bytes.Equal(value, sc.value) {
+			return mismatch(prefix+"parameter.value", hex.EncodeToString(sc.value), hex.EncodeToString(value))
+		}
+	}
+
+	if unverifiable != nil {
+		return unverifiable
+	}
+
+	// Then as a whole, for the fields not compared above.
+	encoded, err := EncodeRawData(claimed, tx.Visible)
+	if err != nil {
+		return fmt.Errorf("transaction %s: %w", tx.TxId, err)
+	}
+	if !bytes.Equal(encoded, raw) {
+		return mismatch("raw_data", tx.RawDataHex, hex.EncodeToString(encoded))
+	}
+	return nil
+}
+
+// verifyTxID checks that tx.TxId is the SHA-256 of tx.RawDataHex.
+func verifyTxID(tx *Transaction) error {
+	if tx.TxId == "" || tx.RawDataHex == "" {
+		return &TxIntegrityError{TxID: tx.TxId, Field: "txID", Signed: tx.RawDataHex, Claimed: tx.TxId}
+	}
+
+	hash, err := txHash(tx)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash) != strings.ToLower(tx.TxId) {
+		return &TxIntegrityError{TxID: tx.TxId, Field: "txID", Signed: hex.EncodeToString(hash), Claimed: tx.TxId}
+	}
+	return nil
+}
+
+// verifyTxHex checks that txID is the SHA-256 of the raw data of the
+// protobuf encoded transaction txHex.
+func verifyTxHex(txHex, txID string) error {
+	b, err := hex.DecodeString(txHex)
+	if err != nil {
+		return err
+	}
+	fields, err := decodeProto(b)
+	if err != nil {
+		return err
+	}
+
+	var raw []byte
+	for _, f := range fields {
+		if f.num == 1 {
+			raw = f.bytes
+		}
+	}
+	return verifyTxID(&Transaction{TxId: txID, RawDataHex: hex.EncodeToString(raw)})
+}
+
+// decodedRaw is the part of a protobuf encoded Transaction.raw checked
+// by VerifyTx.
+type decodedRaw struct {
+	refBlockBytes []byte
+	refBlockHash  []byte
+	expiration    int64
+	data          []byte
+	contracts     []decodedContract
+	timestamp     int64
+	feeLimit      int64
+}
+
+type decodedContract struct {
+	typeURL      string
+	value        []byte
+	permissionID int64
+}
+
+func decodeRaw(b []byte) (*decodedRaw, error) {
+	fields, err := decodeProto(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw decodedRaw
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			raw.refBlockBytes = f.bytes
+		case 4:
+			raw.refBlockHash = f.bytes
+		case 8:
+			raw.expiration = int64(f.varint)
+		case 10:
+			raw.data = f.bytes
+		case 11:
+			c, err := decodeContract(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			raw.contracts = append(raw.contracts, *c)
+		case 14:
+			raw.timestamp = int64(f.varint)
+		case 18:
+			raw.feeLimit = int64(f.varint)
+		}
+	}
+	return &raw, nil
+}
+
+func decodeContract(b []byte) (*decodedContract, error) {
+	fields, err := decodeProto(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var c decodedContract
+	for _, f := range fields {
+		switch f.num {
+		case 2: // google.protobuf.Any
+			param, err := decodeProto(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			for _, a := range param {
+				switch a.num {
+				case 1:
+					c.typeURL = string(a.bytes)
+				case 2:
+					c.value = a.bytes
+				}
+			}
+		case 5:
+			c.permissionID = int64(f.varint)
+		}
+	}
+	return &c, nil
+}
@@ -0,0 +1,111 @@
+package tronhttpClient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// proposalTx returns a transaction creating a proposal, a contract type
+// without a registered value struct, as the node returns it.
+func proposalTx(t *testing.T) *Transaction {
+	t.Helper()
+	// owner_address, then the parameters map entry {0: 1}.
+	value, _ := hex.DecodeString("0a1541c8599111f29c1e1e061265b4af93ea1f274ad78a120408001001")
+	raw := RawData{
+		RefBlockBytes: "e0a3",
+		RefBlockHash:  "0102030405060708",
+		Expiration:    4102444800000,
+		Timestamp:     4102444740000,
+		Contract: []Contract{{
+			Type:      ProposalCreateContract,
+			Parameter: ContractParameter{TypeURL: ProposalCreateContract.TypeURL(), Value: ProtobufValue(value)},
+		}},
+	}
+	b, err := EncodeRawData(&raw, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := sha256.Sum256(b)
+
+	tx := &Transaction{TxId: hex.EncodeToString(id[:]), RawDataHex: hex.EncodeToString(b)}
+	err = json.Unmarshal([]byte(`{"ref_block_bytes":"e0a3","ref_block_hash":"0102030405060708","expiration":4102444800000,"timestamp":4102444740000,`+
+		`"contract":[{"type":"ProposalCreateContract","parameter":{"type_url":"type.googleapis.com/protocol.ProposalCreateContract",`+
+		`"value":{"owner_address":"41c8599111f29c1e1e061265b4af93ea1f274ad78a","parameters":[{"key":0,"value":1}]}}}]}`), &tx.RawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestVerifyTxUnverifiable(t *testing.T) {
+	var integrity *TxIntegrityError
+
+	tx := proposalTx(t)
+	if err := VerifyTx(tx); !errors.Is(err, ErrUnverifiable) || errors.As(err, &integrity) {
+		t.Errorf("VerifyTx = %v, want ErrUnverifiable", err)
+	}
+
+	// A mismatch in the verifiable fields is still reported as such.
+	tx.RawData.Expiration++
+	if err := VerifyTx(tx); !errors.As(err, &integrity) {
+		t.Errorf("VerifyTx of tampered raw data = %v, want a *TxIntegrityError", err)
+	}
+
+	tx = proposalTx(t)
+	tx.TxId = tx.TxId[2:] + "00"
+	if err := VerifyTx(tx); !errors.As(err, &integrity) {
+		t.Errorf("VerifyTx of tampered txID = %v, want a *TxIntegrityError", err)
+	}
+}
+
+func TestSignTxUnverifiable(t *testing.T) {
+	const key = "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28"
+
+	tx := proposalTx(t)
+	if _, err := SignTx(tx, key); !errors.Is(err, ErrUnverifiable) {
+		t.Errorf("SignTx = %v, want ErrUnverifiable", err)
+	}
+	if len(tx.Signature) != 0 {
+		t.Fatalf("SignTx signed an unverifiable transaction")
+	}
+
+	if _, err := SignTxWithOptions(tx, key, &SignOptions{AllowUnverifiable: true}); err != nil {
+		t.Fatalf("SignTxWithOptions: %v", err)
+	}
+	signers, err := RecoverSigners(tx)
+	if err != nil || len(signers) != 1 || signers[0] != "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH" {
+		t.Errorf("RecoverSigners = %v, %v", signers, err)
+	}
+}
+
+// A contract of a type unknown to the package, decoded from JSON by name,
+// is unverifiable too.
+func TestVerifyTxUnknownType(t *testing.T) {
+	raw := RawData{
+		RefBlockBytes: "e0a3",
+		RefBlockHash:  "0102030405060708",
+		Expiration:    4102444800000,
+		Contract: []Contract{{
+			Type:      ContractType(70),
+			Parameter: ContractParameter{TypeURL: "type.googleapis.com/protocol.FutureContract", Value: ProtobufValue{0x08, 0x01}},
+		}},
+	}
+	b, err := EncodeRawData(&raw, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := sha256.Sum256(b)
+
+	tx := &Transaction{TxId: hex.EncodeToString(id[:]), RawDataHex: hex.EncodeToString(b)}
+	err = json.Unmarshal([]byte(`{"ref_block_bytes":"e0a3","ref_block_hash":"0102030405060708","expiration":4102444800000,`+
+		`"contract":[{"type":"FutureContract","parameter":{"type_url":"type.googleapis.com/protocol.FutureContract","value":{"x":1}}}]}`), &tx.RawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTx(tx); !errors.Is(err, ErrUnverifiable) {
+		t.Errorf("VerifyTx = %v, want ErrUnverifiable", err)
+	}
+}